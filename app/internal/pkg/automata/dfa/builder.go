@@ -27,8 +27,12 @@
 package dfa
 
 import (
+	"maps"
+	"slices"
+
 	"github.com/kdeconinck/align/internal/pkg/automata/nfa"
 	"github.com/kdeconinck/align/internal/pkg/collections/queue"
+	"github.com/kdeconinck/align/internal/pkg/collections/set"
 )
 
 // A builder for creating a [Dfa] from a [nfa.Nfa] using the "Subset Construction" algorithm.
@@ -36,27 +40,126 @@ type dfaBuilder[S comparable, V any] struct {
 	dfa                 *Dfa[S, V]
 	workingQueue        *queue.Queue[[]*nfa.State[S, V]]
 	subsetKeyToStateMap map[string]*State[S, V]
+	stateLimit          int                  // The maximum amount of states (0 means unlimited).
+	shortestPrefixes    map[int]set.Set[int] // The states that lead to each shortest accepting state (by ID).
 }
 
 // Returns a [Dfa] that's equivalent to nfa.
-func (builder *dfaBuilder[S, V]) buildFromNfa(n *nfa.Nfa[S, V]) *Dfa[S, V] {
+// Returns [ErrStateLimit] if the [Dfa] requires more states than the builder's limit allows.
+// Returns [ErrTooManyClasses] if n has more distinct classes than [maxClassesPerState].
+func (builder *dfaBuilder[S, V]) buildFromNfa(n *nfa.Nfa[S, V]) (*Dfa[S, V], error) {
+	classCount := countClasses(n)
+
+	if classCount > maxClassesPerState {
+		return nil, ErrTooManyClasses
+	}
+
 	startStates := findPossibleStates(n.Start())
+	builder.shortestPrefixes = findShortestPrefixes(n)
 
 	builder.dfa.start = builder.buildStartState(startStates)
 
+	for anchors := range builder.dfa.starts {
+		builder.dfa.starts[anchors] = builder.ensureState(findPossibleStatesAt(nfa.Anchor(anchors), n.Start()))
+	}
+
+	if err := builder.expandQueued(); err != nil {
+		return nil, err
+	}
+
+	if classCount > 0 {
+		// The transitions for combinations of classes are constructed on first use (see [State.OutgoingFor]).
+		builder.dfa.builder = builder
+	}
+
+	return builder.dfa, nil
+}
+
+// Expands each subset in the builder's working queue into the transitions of its [State] (see
+// [dfaBuilder.expand]), until the queue is empty.
+// Returns [ErrStateLimit] if the [Dfa] requires more states than the builder's limit allows.
+func (builder *dfaBuilder[S, V]) expandQueued() error {
 	for builder.workingQueue.Len() > 0 {
+		if builder.stateLimit > 0 && builder.dfa.nextStateID > builder.stateLimit {
+			return ErrStateLimit
+		}
+
 		currentSubset, _ := builder.workingQueue.Dequeue()
+		builder.expand(currentSubset)
+	}
+
+	if builder.stateLimit > 0 && builder.dfa.nextStateID > builder.stateLimit {
+		return ErrStateLimit
+	}
+
+	return nil
+}
+
+// Constructs the explicit transitions of the [State] that represents currentSubset.
+// The transitions for the classes of the [State] are NOT constructed, since there are 2^k combinations for k classes.
+// Instead, the [nfa.State]s that each class leads to are stored, so that the transition for a combination can be
+// constructed when a symbol requires it (see [State.OutgoingFor]).
+func (builder *dfaBuilder[S, V]) expand(currentSubset []*nfa.State[S, V]) {
+	sKey := calculateStatesKey(currentSubset)
+	from := builder.subsetKeyToStateMap[sKey]
+	currentSubset = builder.stopShortest(currentSubset)
+
+	for sym, nextSubset := range expandStatesPerSymbol(currentSubset) {
+		to := builder.ensureState(nextSubset)
+		from.transitions[sym] = to
+	}
+
+	classes, targets := expandStatesPerClass(currentSubset)
 
-		sKey := calculateStatesKey(currentSubset)
-		from := builder.subsetKeyToStateMap[sKey]
+	if len(classes) > 0 {
+		from.classes = classes
+		from.classTargets = targets
+		from.classTransitions.Store(&map[uint64]*State[S, V]{})
+	}
+}
+
+// Returns the [State] that's reachable from the [State] from for the symbols that are a member of exactly the classes
+// in mask, constructing it (and the states that are reachable from it by explicit transitions) on first use.
+//
+// Returns nil if the construction requires more states than the builder's limit allows. From then on, no states are
+// constructed anymore and [Dfa.Err] returns [ErrStateLimit].
+//
+// NOTE: This is safe for concurrent use. The constructed transitions are published as a new map, so that reading the
+// existing transitions doesn't require a lock.
+func (builder *dfaBuilder[S, V]) classTransition(from *State[S, V], mask uint64) *State[S, V] {
+	builder.dfa.mu.Lock()
+	defer builder.dfa.mu.Unlock()
+
+	transitions := *from.classTransitions.Load()
+
+	if to, ok := transitions[mask]; ok || builder.dfa.err != nil {
+		return to
+	}
+
+	var classStates []*nfa.State[S, V]
 
-		for sym, nextSubset := range expandStatesPerSymbol(currentSubset) {
-			to := builder.ensureState(nextSubset)
-			from.transitions[sym] = to
+	for idx := range from.classes {
+		if mask&(1<<idx) != 0 {
+			classStates = append(classStates, from.classTargets[idx]...)
 		}
 	}
 
-	return builder.dfa
+	nextStateID := builder.dfa.nextStateID
+	to := builder.ensureState(findPossibleStates(classStates...))
+
+	if err := builder.expandQueued(); err != nil {
+		// NOTE: The states that were constructed by this call are never published, so they're discarded.
+		builder.dfa.nextStateID, builder.dfa.err = nextStateID, err
+		builder.workingQueue, builder.subsetKeyToStateMap = nil, nil
+
+		return nil
+	}
+
+	next := maps.Clone(transitions)
+	next[mask] = to
+	from.classTransitions.Store(&next)
+
+	return to
 }
 
 // Build a [State] from states.
@@ -66,11 +169,14 @@ func (builder *dfaBuilder[S, V]) buildStartState(states []*nfa.State[S, V]) *Sta
 
 	sState := &State[S, V]{
 		id:          0, // start is always 0
+		dfa:         builder.dfa,
 		transitions: make(map[S]*State[S, V]),
 		acceptIdx:   acceptingIdx,
 		value:       acceptingValue,
 	}
 
+	sState.otherAcceptIdxs = findOtherAcceptanceIdxs(states, acceptingIdx)
+	sState.anchoredAccepts = findAnchoredAccepts(states)
 	sKey := calculateStatesKey(states)
 
 	builder.subsetKeyToStateMap[sKey] = sState
@@ -91,6 +197,8 @@ func (builder *dfaBuilder[S, V]) ensureState(states []*nfa.State[S, V]) *State[S
 
 	if acceptingIdx > -1 {
 		state := builder.dfa.newAcceptingState(acceptingIdx, acceptingValue)
+		state.otherAcceptIdxs = findOtherAcceptanceIdxs(states, acceptingIdx)
+		state.anchoredAccepts = findAnchoredAccepts(states)
 		builder.subsetKeyToStateMap[sKey] = state
 		builder.workingQueue.Enqueue(states)
 
@@ -98,8 +206,43 @@ func (builder *dfaBuilder[S, V]) ensureState(states []*nfa.State[S, V]) *State[S
 	}
 
 	state := builder.dfa.newState()
+	state.anchoredAccepts = findAnchoredAccepts(states)
 	builder.subsetKeyToStateMap[sKey] = state
 	builder.workingQueue.Enqueue(states)
 
 	return state
 }
+
+// Returns states without the states that lead to the shortest accepting states in states (see
+// [nfa.Nfa.MarkShortest]), since the patterns of those states don't match any further.
+func (builder *dfaBuilder[S, V]) stopShortest(states []*nfa.State[S, V]) []*nfa.State[S, V] {
+	var stopped set.Set[int]
+	var owned bool // Indicates whether stopped is a set of its own (instead of a set of the builder).
+
+	for _, s := range states {
+		if !s.IsShortest() {
+			continue
+		}
+
+		switch prefixes := builder.shortestPrefixes[s.ID()]; {
+		case stopped.Len() == 0:
+			stopped = prefixes
+
+		case !owned:
+			stopped, owned = stopped.Union(prefixes), true
+
+		default:
+			for id := range prefixes.All() {
+				stopped.Add(id)
+			}
+		}
+	}
+
+	if stopped.Len() == 0 {
+		return states
+	}
+
+	return slices.DeleteFunc(slices.Clone(states), func(s *nfa.State[S, V]) bool {
+		return stopped.Has(s.ID())
+	})
+}
@@ -27,29 +27,85 @@
 package dfa
 
 import (
+	"errors"
+	"sync"
+
 	"github.com/kdeconinck/align/internal/pkg/automata/nfa"
 	"github.com/kdeconinck/align/internal/pkg/collections/queue"
 )
 
+// ErrStateLimit is the error returned when the conversion into a [Dfa] requires more states than the limit allows.
+var ErrStateLimit = errors.New("dfa: state limit exceeded")
+
+// ErrTooManyClasses is the error returned when the conversion into a [Dfa] involves more distinct classes (see
+// [nfa.Class]) than a [State] can hold.
+var ErrTooManyClasses = errors.New("dfa: too many distinct classes")
+
 // Dfa represents a deterministic finite automaton for symbols of type S.
 type Dfa[S comparable, V any] struct {
 	start       *State[S, V]
+	starts      [nfa.StartAnchors + 1]*State[S, V] // The start states, indexed by the start anchors that hold.
 	nextStateID int
+	builder     *dfaBuilder[S, V] // Constructs the transitions for combinations of classes on first use (if any).
+	mu          sync.Mutex        // Guards the lazy construction of states.
+	err         error             // The error of the lazy construction of states (if any).
 }
 
 // FromNfa converts and returns n into an equivalent [Dfa] using the "Subset Construction" algorithm.
 func FromNfa[S comparable, V any](n *nfa.Nfa[S, V]) *Dfa[S, V] {
+	d, _ := FromNfaWithLimit(n, 0)
+
+	return d
+}
+
+// FromNfaWithLimit converts and returns n into an equivalent [Dfa] using the "Subset Construction" algorithm.
+// The construction is aborted with [ErrStateLimit] as soon as the [Dfa] needs more than stateLimit states.
+// A limit of 0 means that there's no limit.
+//
+// The limit also applies to the states for combinations of classes (see [nfa.Class]), which are constructed when
+// they're first used. Once those exceed the limit, the transitions that require new states are absent, and [Dfa.Err]
+// returns [ErrStateLimit].
+//
+// NOTE: The "Subset Construction" algorithm can require a number of states that's exponential in the size of n. A
+// limit guards against exhausting memory for such automata.
+func FromNfaWithLimit[S comparable, V any](n *nfa.Nfa[S, V], stateLimit int) (*Dfa[S, V], error) {
 	dfaBuilder := &dfaBuilder[S, V]{
 		dfa: &Dfa[S, V]{
 			nextStateID: 1,
 		},
 		workingQueue:        queue.New[[]*nfa.State[S, V]](),
 		subsetKeyToStateMap: make(map[string]*State[S, V]),
+		stateLimit:          stateLimit,
 	}
 
 	return dfaBuilder.buildFromNfa(n)
 }
 
+// Len returns the amount of states in the Dfa (including the start state).
+// The states for combinations of classes (see [nfa.Class]) are constructed when they're first used, so the amount of
+// states grows as symbols are consumed (up to the state limit, see [FromNfaWithLimit]).
+func (d *Dfa[S, V]) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.nextStateID
+}
+
+// Err returns [ErrStateLimit] if the states for combinations of classes (see [nfa.Class]) exceeded the state limit
+// when they were constructed (see [FromNfaWithLimit]), or nil otherwise.
+func (d *Dfa[S, V]) Err() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.err
+}
+
+// StartAt returns the start [State] of the dfa for a match that starts where the start anchors of anchors hold (see
+// [nfa.StartAnchors]). The other anchors are ignored.
+func (d *Dfa[S, V]) StartAt(anchors nfa.Anchor) *State[S, V] {
+	return d.starts[anchors&nfa.StartAnchors]
+}
+
 // Start returns the Dfa's start [State].
 func (d *Dfa[S, V]) Start() *State[S, V] {
 	return d.start
@@ -62,6 +118,7 @@ func (d *Dfa[S, V]) newState() *State[S, V] {
 
 	return &State[S, V]{
 		id:          id,
+		dfa:         d,
 		transitions: make(map[S]*State[S, V]),
 		acceptIdx:   -1,
 	}
@@ -74,6 +131,7 @@ func (d *Dfa[S, V]) newAcceptingState(acceptIdx int, value V) *State[S, V] {
 
 	return &State[S, V]{
 		id:          id,
+		dfa:         d,
 		transitions: make(map[S]*State[S, V]),
 		acceptIdx:   acceptIdx,
 		value:       value,
@@ -27,6 +27,7 @@
 package dfa_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/kdeconinck/align/internal/pkg/assert"
@@ -624,6 +625,368 @@ func TestDfa_FromNfa_BuildWithLoop(t *testing.T) {
 	})
 }
 
+// UT: Build an [nfa.Nfa] using class transitions and convert it to a [dfa.Dfa].
+func TestDfa_FromNfa_BuildWithClasses(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	nMachine := nfa.New[string, int]()
+	sState := nMachine.Start()
+	vState := nMachine.AddClass(sState, vowels)
+	nMachine.AddAcceptingEpsilonTransition(vState, 10)
+	lState := nMachine.AddClass(sState, letters)
+	nMachine.AddAcceptingEpsilonTransition(lState, 20)
+	aState := nMachine.Add(sState, "a")
+	nMachine.AddAcceptingEpsilonTransition(aState, 30)
+
+	dMachine := dfa.FromNfa(nMachine)
+	dSState := dMachine.Start()
+
+	for tcName, tc := range map[string]struct {
+		symbol string
+		want   int
+	}{
+		"Consuming the symbol 'e' (a vowel and a letter) leads to the 'State' with the lowest acceptance index.": {
+			symbol: "e",
+			want:   10,
+		},
+		"Consuming the symbol 'x' (a letter) leads to the 'State' of the letter class.": {
+			symbol: "x",
+			want:   20,
+		},
+		"Consuming the symbol 'a' (explicit transition) leads to the 'State' with the lowest acceptance index.": {
+			symbol: "a",
+			want:   10,
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			state := dSState.OutgoingFor(tc.symbol)
+
+			assert.NotNilf(t, state, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[31mFatal error: Consuming '%s' should lead to a 'State'.\033[0m\n\n", tcName, tc.symbol)
+
+			got := state.AcceptValue()
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d.\033[0m\n"+
+				"\033[31mActual:   %d.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+
+	t.Run("Consuming a symbol that's NOT a member of any class leads to <nil>.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Act.
+		got := dSState.OutgoingFor("1")
+
+		// Assert.
+		assert.Nilf(t, got, "\n\n"+
+			"UT Name:  Consuming a symbol that's NOT a member of any class leads to <nil>.\n"+
+			"\033[32mExpected: <nil>.\033[0m\n"+
+			"\033[31mActual:   NOT <nil>.\033[0m\n\n")
+	})
+}
+
+// UT: Convert an [nfa.Nfa] with anchor based transitions into a [dfa.Dfa].
+func TestDfa_FromNfa_BuildWithAnchors(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	// The nfa accepts "a" at the start of a line (10), "a" at the start of the input (20), "b" before a line break
+	// (30), "b" at the end of the input (40) and "b" anywhere (50).
+	nMachine := nfa.New[string, int]()
+	sState := nMachine.Start()
+	nMachine.AddAccepting(nMachine.AddAnchor(sState, nfa.AnchorLineStart), "a", 10)
+	nMachine.AddAccepting(nMachine.AddAnchor(sState, nfa.AnchorInputStart), "a", 20)
+	nMachine.AddAcceptingEpsilonTransition(nMachine.AddAnchor(nMachine.Add(sState, "b"), nfa.AnchorLineEnd), 30)
+	nMachine.AddAcceptingEpsilonTransition(nMachine.AddAnchor(nMachine.Add(sState, "b"), nfa.AnchorInputEnd), 40)
+	nMachine.AddAccepting(sState, "b", 50)
+
+	dMachine := dfa.FromNfa(nMachine)
+
+	for tcName, tc := range map[string]struct {
+		anchors nfa.Anchor
+		want    []int
+	}{
+		"Starting where NO anchor holds, the start anchors aren't followed.": {
+			anchors: 0,
+			want:    nil,
+		},
+		"Starting at the start of a line, the line start anchor is followed.": {
+			anchors: nfa.AnchorLineStart,
+			want:    newSlice(0),
+		},
+		"Starting at the start of the input, both start anchors are followed.": {
+			anchors: nfa.AnchorLineStart | nfa.AnchorInputStart,
+			want:    newSlice(0, 1),
+		},
+		"Starting before a line break, the end anchors are ignored.": {
+			anchors: nfa.AnchorLineEnd,
+			want:    nil,
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			var got []int
+
+			if state := dMachine.StartAt(tc.anchors).OutgoingFor("a"); state != nil {
+				got = state.AcceptIdxs()
+			}
+
+			// Assert.
+			assert.EqualSf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+
+	t.Run("The acceptances behind end anchors depend on the anchors.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Act.
+		state := dMachine.Start().OutgoingFor("b")
+		got := state.AnchoredAccepts()
+		want := newSlice(
+			dfa.AnchoredAccept{Anchors: nfa.AnchorLineEnd, AcceptIdx: 2},
+			dfa.AnchoredAccept{Anchors: nfa.AnchorInputEnd, AcceptIdx: 3},
+		)
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  The acceptances behind end anchors depend on the anchors.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+
+		assert.Equalf(t, state.AcceptValue(), 50, "\n\n"+
+			"UT Name:  The acceptances behind end anchors don't affect the other acceptances.\n"+
+			"\033[32mExpected: %d.\033[0m\n"+
+			"\033[31mActual:   %d.\033[0m\n\n", 50, state.AcceptValue())
+	})
+}
+
+// UT: Convert an [nfa.Nfa] to a [dfa.Dfa] with a state limit.
+func TestDfa_FromNfaWithLimit(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	nMachine := nfa.New[string, int]()
+	cState := nMachine.Start()
+
+	for range 10 {
+		cState = nMachine.Add(cState, "a")
+	}
+
+	t.Run("When the limit is NOT exceeded, the 'Dfa' is returned.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Act.
+		dMachine, err := dfa.FromNfaWithLimit(nMachine, 11)
+
+		// Assert.
+		assert.Truef(t, err == nil && dMachine.Len() == 11, "\n\n"+
+			"UT Name:  When the limit is NOT exceeded, the 'Dfa' is returned.\n"+
+			"\033[32mExpected: A 'Dfa' with 11 states and NO error.\033[0m\n"+
+			"\033[31mActual:   Error: %v.\033[0m\n\n", err)
+	})
+
+	t.Run("When the limit is exceeded, an error is returned.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Act.
+		_, got := dfa.FromNfaWithLimit(nMachine, 10)
+
+		// Assert.
+		assert.Errorf(t, got, dfa.ErrStateLimit, "\n\n"+
+			"UT Name:  When the limit is exceeded, an error is returned.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", dfa.ErrStateLimit, got)
+	})
+}
+
+// UT: Construct the states for combinations of classes of a [dfa.Dfa] that has a limit.
+func TestDfa_FromNfaWithLimit_Classes(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	nMachine := nfa.New[string, int]()
+	sState := nMachine.Start()
+	nMachine.AddAcceptingEpsilonTransition(nMachine.AddClass(sState, vowels), 10)
+	nMachine.AddAcceptingEpsilonTransition(nMachine.AddClass(sState, letters), 20)
+
+	dMachine, err := dfa.FromNfaWithLimit(nMachine, 2)
+
+	assert.Nilf(t, err, "\n\n"+
+		"UT Name:  The states for combinations of classes of a 'Dfa' with a limit are constructed up to the limit.\n"+
+		"\033[31mFatal error: The 'Dfa' should be built, got %v.\033[0m\n\n", err)
+
+	// Act.
+	vState := dMachine.Start().OutgoingFor("e")
+	lState := dMachine.Start().OutgoingFor("x")
+	got := dMachine.Err()
+
+	// Assert.
+	assert.Truef(t, vState != nil && lState == nil && dMachine.Len() == 2, "\n\n"+
+		"UT Name:  The states for combinations of classes of a 'Dfa' with a limit are constructed up to the limit.\n"+
+		"\033[32mExpected: A 'State' for 'e', NO 'State' for 'x' and 2 states.\033[0m\n"+
+		"\033[31mActual:   %v, %v and %d states.\033[0m\n\n", vState, lState, dMachine.Len())
+
+	assert.Errorf(t, got, dfa.ErrStateLimit, "\n\n"+
+		"UT Name:  Exceeding the limit while constructing the states for combinations of classes is reported.\n"+
+		"\033[32mExpected: %v.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", dfa.ErrStateLimit, got)
+}
+
+// UT: Build an [nfa.Nfa] with an epsilon cycle and convert it to a [dfa.Dfa].
+func TestDfa_FromNfa_BuildWithEpsilonCycle(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	nMachine := nfa.New[string, int]()
+	sState := nMachine.Start()
+	eState := nMachine.AddEpsilonTransition(sState)
+	nMachine.ConnectEpsilon(eState, sState)
+	nMachine.AddAccepting(eState, "a", 10)
+
+	// Act.
+	dMachine := dfa.FromNfa(nMachine)
+	got, want := dMachine.Start().OutgoingFor("a").AcceptValue(), 10
+
+	// Assert.
+	assert.Equalf(t, got, want, "\n\n"+
+		"UT Name:  When converting an 'Nfa' with an epsilon cycle, the conversion terminates.\n"+
+		"\033[32mExpected: %d.\033[0m\n"+
+		"\033[31mActual:   %d.\033[0m\n\n", want, got)
+}
+
+// UT: Verify that all the acceptance indexes of a [dfa.State] are returned.
+func TestDfaState_AcceptIdxs(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	nMachine := nfa.New[string, int]()
+	sState := nMachine.Start()
+	nMachine.AddAccepting(sState, "a", 10)
+	nMachine.AddAccepting(sState, "b", 20)
+	nMachine.AddAccepting(sState, "a", 30)
+
+	dMachine := dfa.FromNfa(nMachine)
+	dSState := dMachine.Start()
+
+	for tcName, tc := range map[string]struct {
+		state *dfa.State[string, int]
+		want  []int
+	}{
+		"For a 'State' that's NOT accepting, the 'AcceptIdxs' operation returns <nil>.": {
+			state: dSState,
+			want:  nil,
+		},
+		"For a 'State' that represents a single accepting 'State', the 'AcceptIdxs' operation returns its index.": {
+			state: dSState.OutgoingFor("b"),
+			want:  newSlice(1),
+		},
+		"For a 'State' that represents multiple accepting 'State's, the 'AcceptIdxs' operation returns all indexes.": {
+			state: dSState.OutgoingFor("a"),
+			want:  newSlice(0, 2),
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := tc.state.AcceptIdxs()
+
+			// Assert.
+			assert.EqualSf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Convert an [nfa.Nfa] with a shortest accepting [nfa.State] into a [dfa.Dfa].
+func TestDfa_FromNfa_BuildWithShortest(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	// The nfa accepts "a" followed by any amount of "a" and "b" and ends with "b" (e.g., "ab", "aab" or "abab"), and
+	// "abb" as a separate pattern.
+	nMachine := nfa.New[string, int]()
+	sState := nMachine.Start()
+	loop := nMachine.Add(sState, "a")
+	nMachine.Connect(loop, "a", loop)
+	nMachine.Connect(loop, "b", loop)
+	nMachine.MarkShortest(nMachine.AddAccepting(loop, "b", 10))
+	nMachine.AddAccepting(nMachine.Add(nMachine.Add(sState, "a"), "b"), "b", 20)
+
+	dMachine := dfa.FromNfa(nMachine)
+	walk := func(symbols ...string) *dfa.State[string, int] {
+		state := dMachine.Start()
+
+		for _, sym := range symbols {
+			if state = state.OutgoingFor(sym); state == nil {
+				return nil
+			}
+		}
+
+		return state
+	}
+
+	t.Run("The shortest match of the pattern is accepted.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Act.
+		got, want := walk("a", "a", "b").AcceptValue(), 10
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  The shortest match of the pattern is accepted.\n"+
+			"\033[32mExpected: %d.\033[0m\n"+
+			"\033[31mActual:   %d.\033[0m\n\n", want, got)
+	})
+
+	t.Run("The pattern doesn't match beyond its shortest match.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Act.
+		got := walk("a", "a", "b", "b")
+
+		// Assert.
+		assert.Nilf(t, got, "\n\n"+
+			"UT Name:  The pattern doesn't match beyond its shortest match.\n"+
+			"\033[32mExpected: <nil>.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", got)
+	})
+
+	t.Run("Other patterns match beyond the shortest match of the pattern.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Act.
+		got, want := walk("a", "b", "b").AcceptValue(), 20
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  Other patterns match beyond the shortest match of the pattern.\n"+
+			"\033[32mExpected: %d.\033[0m\n"+
+			"\033[31mActual:   %d.\033[0m\n\n", want, got)
+	})
+}
+
 // UT: Verify that copies of elements are returned.
 func TestDfa_CopySemantics(t *testing.T) {
 	t.Parallel() // Enable parallel execution.
@@ -706,6 +1069,22 @@ func newSlice[T any](args ...T) []T {
 	return container
 }
 
+// Classes used to verify class transitions.
+var (
+	vowels  = &symbolClass{symbols: "aeiou"}
+	letters = &symbolClass{symbols: "abcdefghijklmnopqrstuvwxyz"}
+)
+
+// An [nfa.Class] containing each (single character) symbol in symbols.
+type symbolClass struct {
+	symbols string
+}
+
+// Contains reports whether sym is a member of the class.
+func (class *symbolClass) Contains(sym string) bool {
+	return len(sym) == 1 && strings.Contains(class.symbols, sym)
+}
+
 var benchmarkOutput int // Output of the benchmark(s). Used to avoid compiler optimizations.
 
 // Benchmark(s): Convert a linear [nfa.Nfa] to a [dfa.Dfa].
@@ -26,12 +26,33 @@
 // Package dfa implements a deterministic finite automaton.
 package dfa
 
+import (
+	"sync/atomic"
+
+	"github.com/kdeconinck/align/internal/pkg/automata/nfa"
+)
+
 // State is a node in a [Dfa].
 type State[S comparable, V any] struct {
-	id          int
-	transitions map[S]*State[S, V]
-	acceptIdx   int
-	value       V // The accepting value (if any).
+	id               int
+	dfa              *Dfa[S, V]
+	transitions      map[S]*State[S, V]
+	classes          []nfa.Class[S]                          // The distinct classes with an outgoing transition (if any).
+	classTargets     [][]*nfa.State[S, V]                    // The nfa states that each class leads to.
+	classTransitions atomic.Pointer[map[uint64]*State[S, V]] // Transitions keyed by a bitmask of the classes containing a symbol.
+	acceptIdx        int
+	otherAcceptIdxs  []int            // The acceptance indexes (in ascending order) of the other accepting nfa states (if any).
+	anchoredAccepts  []AnchoredAccept // The acceptances that depend on end anchors (if any).
+	value            V                // The accepting value (if any).
+}
+
+// AnchoredAccept is an acceptance of a [State] that only applies where end anchors hold (see [nfa.EndAnchors]).
+type AnchoredAccept struct {
+	// Anchors are the end anchors that must hold.
+	Anchors nfa.Anchor
+
+	// AcceptIdx is the acceptance index of the accepting [nfa.State].
+	AcceptIdx int
 }
 
 // ID returns the unique, builder-assigned identifier (starting at 0).
@@ -51,8 +72,33 @@ func (s *State[S, V]) OutgoingSymbols() []S {
 }
 
 // OutgoingFor returns the [State] reachable by consuming symbol, or nil if none.
+// Symbols that have an explicit transition are resolved directly. Any other symbol is tested against the classes of
+// the state (see [nfa.Class]). The transition for a combination of classes is constructed when it's first used, unless
+// that exceeds the state limit (see [Dfa.Err]), in which case nil is returned.
+//
+// NOTE: This is safe for concurrent use.
 func (s *State[S, V]) OutgoingFor(symbol S) *State[S, V] {
-	return s.transitions[symbol]
+	if state, ok := s.transitions[symbol]; ok || len(s.classes) == 0 {
+		return state
+	}
+
+	var mask uint64
+
+	for idx, class := range s.classes {
+		if class.Contains(symbol) {
+			mask |= 1 << idx
+		}
+	}
+
+	if mask == 0 {
+		return nil
+	}
+
+	if to, ok := (*s.classTransitions.Load())[mask]; ok {
+		return to
+	}
+
+	return s.dfa.builder.classTransition(s, mask)
 }
 
 // AcceptIdx returns the accepted index.
@@ -60,6 +106,16 @@ func (s *State[S, V]) AcceptIdx() int {
 	return s.acceptIdx
 }
 
+// AcceptIdxs returns the acceptance indexes of all the accepting [nfa.State]s that this state represents, in ascending
+// order. The first index is the one returned by [State.AcceptIdx]. If the state is NOT accepting, nil is returned.
+func (s *State[S, V]) AcceptIdxs() []int {
+	if s.acceptIdx <= -1 {
+		return nil
+	}
+
+	return append([]int{s.acceptIdx}, s.otherAcceptIdxs...)
+}
+
 // IsAccepting reports whether the state is accepting.
 func (s *State[S, V]) IsAccepting() bool {
 	return s.AcceptIdx() > -1
@@ -75,3 +131,9 @@ func (s *State[S, V]) AcceptValue() V {
 
 	return s.value
 }
+
+// AnchoredAccepts returns the acceptances of the state that only apply where end anchors hold, ordered by acceptance
+// index (or nil if there are none).
+func (s *State[S, V]) AnchoredAccepts() []AnchoredAccept {
+	return s.anchoredAccepts
+}
@@ -27,7 +27,8 @@
 package dfa
 
 import (
-	"sort"
+	"cmp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -37,11 +38,18 @@ import (
 )
 
 // Returns all the possible [nfa.State]s, reachable from states, by following zero or more epsilon transitions.
+// Each [nfa.State] is returned only once, which also guarantees termination when epsilon transitions form a cycle.
 func findPossibleStates[S comparable, V any](states ...*nfa.State[S, V]) []*nfa.State[S, V] {
 	workingQueue := queue.New[*nfa.State[S, V]]()
 	reachableStates := make([]*nfa.State[S, V], 0, len(states))
+	seen := set.WithCapacity[int](len(states))
 
 	for _, s := range states {
+		if seen.Has(s.ID()) {
+			continue
+		}
+
+		seen.Add(s.ID())
 		workingQueue.Enqueue(s)
 		reachableStates = append(reachableStates, s)
 	}
@@ -50,6 +58,11 @@ func findPossibleStates[S comparable, V any](states ...*nfa.State[S, V]) []*nfa.
 		queuedState, _ := workingQueue.Dequeue()
 
 		for _, nState := range queuedState.Epsilon() {
+			if seen.Has(nState.ID()) {
+				continue
+			}
+
+			seen.Add(nState.ID())
 			workingQueue.Enqueue(nState)
 			reachableStates = append(reachableStates, nState)
 		}
@@ -58,6 +71,158 @@ func findPossibleStates[S comparable, V any](states ...*nfa.State[S, V]) []*nfa.
 	return reachableStates
 }
 
+// Returns all the possible [nfa.State]s, reachable from states, by following zero or more epsilon transitions and
+// transitions on the start anchors that are in anchors (see [nfa.StartAnchors]).
+func findPossibleStatesAt[S comparable, V any](anchors nfa.Anchor, states ...*nfa.State[S, V]) []*nfa.State[S, V] {
+	reachableStates := findPossibleStates(states...)
+	seen := set.WithCapacity[int](len(reachableStates))
+
+	for _, s := range reachableStates {
+		seen.Add(s.ID())
+	}
+
+	for idx := 0; idx < len(reachableStates); idx++ {
+		for _, at := range reachableStates[idx].AnchorTransitions() {
+			if at.Anchor&nfa.StartAnchors != at.Anchor || at.Anchor&anchors != at.Anchor {
+				continue
+			}
+
+			for _, nState := range findPossibleStates(at.To) {
+				if !seen.Has(nState.ID()) {
+					seen.Add(nState.ID())
+					reachableStates = append(reachableStates, nState)
+				}
+			}
+		}
+	}
+
+	return reachableStates
+}
+
+// Returns the acceptances that are reachable from states by following zero or more epsilon transitions and at least
+// one transition on end anchors (see [nfa.EndAnchors]), ordered by acceptance index (or nil if there are none).
+func findAnchoredAccepts[S comparable, V any](states []*nfa.State[S, V]) []AnchoredAccept {
+	type anchoredState struct {
+		state   *nfa.State[S, V]
+		anchors nfa.Anchor
+	}
+
+	var accepts []AnchoredAccept
+	var workingList []anchoredState
+	seen := set.New[anchoredState]()
+
+	for _, s := range states {
+		workingList = append(workingList, anchoredState{state: s})
+	}
+
+	for len(workingList) > 0 {
+		current := workingList[len(workingList)-1]
+		workingList = workingList[:len(workingList)-1]
+
+		if current.anchors != 0 && current.state.IsAccepting() {
+			accept := AnchoredAccept{Anchors: current.anchors, AcceptIdx: current.state.AcceptIdx()}
+
+			if !slices.Contains(accepts, accept) {
+				accepts = append(accepts, accept)
+			}
+		}
+
+		var next []anchoredState
+
+		for _, at := range current.state.AnchorTransitions() {
+			if at.Anchor&nfa.EndAnchors == at.Anchor {
+				next = append(next, anchoredState{state: at.To, anchors: current.anchors | at.Anchor})
+			}
+		}
+
+		if current.anchors != 0 {
+			for _, eState := range current.state.Epsilon() {
+				next = append(next, anchoredState{state: eState, anchors: current.anchors})
+			}
+		}
+
+		for _, n := range next {
+			if !seen.Has(n) {
+				seen.Add(n)
+				workingList = append(workingList, n)
+			}
+		}
+	}
+
+	slices.SortFunc(accepts, func(a, b AnchoredAccept) int {
+		return cmp.Or(cmp.Compare(a.AcceptIdx, b.AcceptIdx), cmp.Compare(a.Anchors, b.Anchors))
+	})
+
+	return accepts
+}
+
+// Returns, for each shortest accepting state of n (see [nfa.Nfa.MarkShortest]), the IDs of the states (except the
+// start state of n) from which it can be reached, including its own ID. The result is indexed by the ID of the
+// accepting state, and it's nil if n doesn't have any shortest accepting states.
+func findShortestPrefixes[S comparable, V any](n *nfa.Nfa[S, V]) map[int]set.Set[int] {
+	shortest := n.Shortest()
+
+	if len(shortest) == 0 {
+		return nil
+	}
+
+	incoming := make(map[int][]*nfa.State[S, V]) // The states with a transition to each state (by ID).
+	workingQueue := queue.New[*nfa.State[S, V]]()
+	seen := set.New[int]()
+
+	seen.Add(n.Start().ID())
+	workingQueue.Enqueue(n.Start())
+
+	for workingQueue.Len() > 0 {
+		queuedState, _ := workingQueue.Dequeue()
+		targets := queuedState.Epsilon()
+
+		for _, sym := range queuedState.OutgoingSymbols() {
+			targets = append(targets, queuedState.OutgoingFor(sym)...)
+		}
+
+		for _, ct := range queuedState.ClassTransitions() {
+			targets = append(targets, ct.To)
+		}
+
+		for _, at := range queuedState.AnchorTransitions() {
+			targets = append(targets, at.To)
+		}
+
+		for _, target := range targets {
+			incoming[target.ID()] = append(incoming[target.ID()], queuedState)
+
+			if !seen.Has(target.ID()) {
+				seen.Add(target.ID())
+				workingQueue.Enqueue(target)
+			}
+		}
+	}
+
+	prefixes := make(map[int]set.Set[int], len(shortest))
+
+	for _, aState := range shortest {
+		prefix := set.New[int]()
+		prefix.Add(aState.ID())
+		workingQueue.Enqueue(aState)
+
+		for workingQueue.Len() > 0 {
+			queuedState, _ := workingQueue.Dequeue()
+
+			for _, source := range incoming[queuedState.ID()] {
+				if source != n.Start() && !prefix.Has(source.ID()) {
+					prefix.Add(source.ID())
+					workingQueue.Enqueue(source)
+				}
+			}
+		}
+
+		prefixes[aState.ID()] = prefix
+	}
+
+	return prefixes
+}
+
 // Returns the acceptance index (and value) in states with the lowest value.
 func findAcceptanceIdx[S comparable, V any](states []*nfa.State[S, V]) (int, V) {
 	var valueV V
@@ -79,24 +244,33 @@ func findAcceptanceIdx[S comparable, V any](states []*nfa.State[S, V]) (int, V)
 	return bestIdx, valueV
 }
 
+// Returns the acceptance indexes in states, except bestIdx, in ascending order (or nil if there are none).
+func findOtherAcceptanceIdxs[S comparable, V any](states []*nfa.State[S, V], bestIdx int) []int {
+	var idxs []int
+
+	for _, s := range states {
+		if idx := s.AcceptIdx(); idx > -1 && idx != bestIdx && !slices.Contains(idxs, idx) {
+			idxs = append(idxs, idx)
+		}
+	}
+
+	slices.Sort(idxs)
+
+	return idxs
+}
+
 // Returns a canonical key for states.
 // NOTE: The key is calculated by sorting the IDs of states and joining them by ','.
 func calculateStatesKey[S comparable, V any](states []*nfa.State[S, V]) string {
-	seen := make(map[int]struct{}, len(states))
-	stateIDs := make([]int, 0, len(states))
+	stateIDs := set.WithCapacity[int](len(states))
 
 	for _, state := range states {
-		id := state.ID()
-
-		seen[id] = struct{}{}
-		stateIDs = append(stateIDs, id)
+		stateIDs.Add(state.ID())
 	}
 
-	sort.Ints(stateIDs)
-
 	var b strings.Builder
 
-	for idx, id := range stateIDs {
+	for idx, id := range set.SortedValues(stateIDs) {
 		if idx > 0 {
 			b.WriteByte(',')
 		}
@@ -119,7 +293,7 @@ func expandStatesPerSymbol[S comparable, V any](states []*nfa.State[S, V]) map[S
 
 	statesPerSymbol := make(map[S][]*nfa.State[S, V])
 
-	for _, sym := range alphabet.Values() {
+	for sym := range alphabet.All() {
 		symbolStates := findReachableStatesForSymbol(states, sym)
 		epsilonStates := findPossibleStates(symbolStates...)
 
@@ -131,6 +305,78 @@ func expandStatesPerSymbol[S comparable, V any](states []*nfa.State[S, V]) map[S
 	return statesPerSymbol
 }
 
+// The maximum amount of distinct classes in an [nfa.Nfa].
+// A combination of classes is represented as a bitmask, so a [State] can't have more than 64 distinct classes.
+const maxClassesPerState = 64
+
+// Returns the amount of distinct classes (see [nfa.Class]) used by the transitions of the [nfa.State]s in n.
+func countClasses[S comparable, V any](n *nfa.Nfa[S, V]) int {
+	var classes []nfa.Class[S]
+
+	workingQueue := queue.New[*nfa.State[S, V]]()
+	seen := set.New[int]()
+
+	seen.Add(n.Start().ID())
+	workingQueue.Enqueue(n.Start())
+
+	for workingQueue.Len() > 0 {
+		queuedState, _ := workingQueue.Dequeue()
+		targets := queuedState.Epsilon()
+
+		for _, sym := range queuedState.OutgoingSymbols() {
+			targets = append(targets, queuedState.OutgoingFor(sym)...)
+		}
+
+		for _, ct := range queuedState.ClassTransitions() {
+			if !slices.Contains(classes, ct.Class) {
+				classes = append(classes, ct.Class)
+			}
+
+			targets = append(targets, ct.To)
+		}
+
+		for _, at := range queuedState.AnchorTransitions() {
+			targets = append(targets, at.To)
+		}
+
+		for _, target := range targets {
+			if !seen.Has(target.ID()) {
+				seen.Add(target.ID())
+				workingQueue.Enqueue(target)
+			}
+		}
+	}
+
+	return len(classes)
+}
+
+// Returns the distinct classes (see [nfa.Class]) used by the transitions of states, together with the [nfa.State]s
+// that each class leads to.
+// A symbol that's a member of a combination of classes leads to the union of those [nfa.State]s (including those
+// reachable by following zero or more epsilon transitions).
+//
+// NOTE: The combinations only apply to symbols that do NOT have an explicit transition.
+func expandStatesPerClass[S comparable, V any](states []*nfa.State[S, V]) ([]nfa.Class[S], [][]*nfa.State[S, V]) {
+	var classes []nfa.Class[S]
+	var targets [][]*nfa.State[S, V]
+
+	for _, state := range states {
+		for _, ct := range state.ClassTransitions() {
+			idx := slices.Index(classes, ct.Class)
+
+			if idx == -1 {
+				idx = len(classes)
+				classes = append(classes, ct.Class)
+				targets = append(targets, nil)
+			}
+
+			targets[idx] = append(targets[idx], ct.To)
+		}
+	}
+
+	return classes, targets
+}
+
 // Returns all the possible [nfa.State]s (starting from states) that are reachable by following transitions for symbol.
 func findReachableStatesForSymbol[S comparable, V any](states []*nfa.State[S, V], symbol S) []*nfa.State[S, V] {
 	var reachableStates []*nfa.State[S, V]
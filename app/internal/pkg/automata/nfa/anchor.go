@@ -0,0 +1,82 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package nfa implements a non-deterministic finite automaton.
+package nfa
+
+// Anchor is a set of zero-width assertions about the location in the input, such as "at the start of a line".
+type Anchor uint8
+
+// The anchors that are supported by an [Nfa].
+// An [AnchorTransition] on a start anchor can only be followed at the start of a match, while an [AnchorTransition]
+// on an end anchor can only be followed at the end of a match.
+const (
+	AnchorLineStart  Anchor = 1 << iota // At the start of a line (or the input).
+	AnchorInputStart                    // At the start of the input.
+	AnchorLineEnd                       // Before a line break (or at the end of the input).
+	AnchorInputEnd                      // At the end of the input.
+)
+
+// The anchors that are evaluated at the start and at the end of a match.
+const (
+	StartAnchors = AnchorLineStart | AnchorInputStart
+	EndAnchors   = AnchorLineEnd | AnchorInputEnd
+)
+
+// AnchorTransition is a transition from a [State] to To that doesn't consume a symbol, but that can only be followed
+// where Anchor holds.
+type AnchorTransition[S comparable, V any] struct {
+	// Anchor is the assertion that must hold to follow the transition.
+	Anchor Anchor
+
+	// To is the target [State] of the transition.
+	To *State[S, V]
+}
+
+// AddAnchor adds and returns a new transition starting from s, which can only be followed where anchor holds.
+// Adding a transition causes a new [State] to be generated.
+func (n *Nfa[S, V]) AddAnchor(s *State[S, V], anchor Anchor) *State[S, V] {
+	state := n.NewState()
+	n.ConnectAnchor(s, anchor, state)
+
+	return state
+}
+
+// ConnectAnchor adds a transition from from to to, which can only be followed where anchor holds.
+func (n *Nfa[S, V]) ConnectAnchor(from *State[S, V], anchor Anchor, to *State[S, V]) {
+	from.anchorTransitions = append(from.anchorTransitions, AnchorTransition[S, V]{Anchor: anchor, To: to})
+}
+
+// AnchorTransitions returns all the anchor based transitions starting from the state.
+func (s *State[S, V]) AnchorTransitions() []AnchorTransition[S, V] {
+	if s.anchorTransitions == nil {
+		return nil
+	}
+
+	out := make([]AnchorTransition[S, V], len(s.anchorTransitions))
+	copy(out, s.anchorTransitions)
+
+	return out
+}
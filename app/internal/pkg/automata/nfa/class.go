@@ -0,0 +1,72 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package nfa implements a non-deterministic finite automaton.
+package nfa
+
+// Class is a (possibly very large) set of symbols that's represented by a single transition in an [Nfa].
+// Classes make it possible to match sets like "all Unicode letters" without adding a transition for each member.
+//
+// NOTE: Implementations MUST be comparable (e.g., a pointer type), since equal classes are merged when converting the
+// [Nfa] into a deterministic automaton.
+type Class[S comparable] interface {
+	// Contains reports whether sym is a member of the class.
+	Contains(sym S) bool
+}
+
+// ClassTransition is a transition from a [State] to To for every symbol that's a member of Class.
+type ClassTransition[S comparable, V any] struct {
+	// Class is the set of symbols for which the transition applies.
+	Class Class[S]
+
+	// To is the target [State] of the transition.
+	To *State[S, V]
+}
+
+// AddClass adds and returns a new transition starting from s for every symbol in class.
+// Adding a transition causes a new [State] to be generated.
+func (n *Nfa[S, V]) AddClass(s *State[S, V], class Class[S]) *State[S, V] {
+	state := n.NewState()
+	n.ConnectClass(s, class, state)
+
+	return state
+}
+
+// ConnectClass adds a transition on every symbol in class from from to to.
+func (n *Nfa[S, V]) ConnectClass(from *State[S, V], class Class[S], to *State[S, V]) {
+	from.classTransitions = append(from.classTransitions, ClassTransition[S, V]{Class: class, To: to})
+}
+
+// ClassTransitions returns all the class based transitions starting from the state.
+func (s *State[S, V]) ClassTransitions() []ClassTransition[S, V] {
+	if s.classTransitions == nil {
+		return nil
+	}
+
+	out := make([]ClassTransition[S, V], len(s.classTransitions))
+	copy(out, s.classTransitions)
+
+	return out
+}
@@ -26,11 +26,22 @@
 // Package nfa implements a non-deterministic finite automaton.
 package nfa
 
+import (
+	"errors"
+	"slices"
+)
+
+// ErrStateLimit is the error reported when an [Nfa] contains more states than its limit allows.
+var ErrStateLimit = errors.New("nfa: state limit exceeded")
+
 // Nfa represents a non-deterministic finite automaton for symbols of type S with acceptance metadata of type V.
 type Nfa[S comparable, V any] struct {
 	start           *State[S, V]
 	nextStateID     int
 	nextAcceptIndex int
+	stateLimit      int            // The maximum amount of states (0 means unlimited).
+	err             error          // The first error that occurred while building the nfa (if any).
+	shortest        []*State[S, V] // The accepting states that end the matching of their pattern.
 }
 
 // New returns a new [Nfa] for symbols of type S with acceptance metadata of type V.
@@ -49,6 +60,29 @@ func New[S comparable, V any]() *Nfa[S, V] {
 // Start returns the start [State] of the nfa.
 func (n *Nfa[S, V]) Start() *State[S, V] { return n.start }
 
+// Len returns the amount of states in the nfa (including the start state).
+func (n *Nfa[S, V]) Len() int { return n.nextStateID }
+
+// SetStateLimit sets the maximum amount of states in the nfa. A limit of 0 means that there's no limit.
+// Exceeding the limit does NOT stop the construction of the nfa, but it's recorded and reported by [Nfa.Err].
+func (n *Nfa[S, V]) SetStateLimit(limit int) {
+	n.stateLimit = limit
+	n.checkLimit(0)
+}
+
+// Err returns the first error that occurred while building the nfa, or nil if there's none.
+// The only error that can occur is [ErrStateLimit].
+func (n *Nfa[S, V]) Err() error { return n.err }
+
+// Reserve reports whether count more states can be added to the nfa without exceeding its limit.
+// When that's not the case, [ErrStateLimit] is recorded (see [Nfa.Err]) and returned.
+// Builders use this to fail early, before constructing large parts that won't fit.
+func (n *Nfa[S, V]) Reserve(count int) error {
+	n.checkLimit(count)
+
+	return n.err
+}
+
 // Epsilon returns the reachable [State]s following epsilon transitions from the state.
 func (s *State[S, V]) Epsilon() []*State[S, V] {
 	if s.eTransitions == nil {
@@ -111,6 +145,7 @@ func (n *Nfa[S, V]) ConnectEpsilon(from *State[S, V], to *State[S, V]) {
 func (n *Nfa[S, V]) NewState() *State[S, V] {
 	id := n.nextStateID
 	n.nextStateID++
+	n.checkLimit(0)
 
 	return &State[S, V]{
 		id:           id,
@@ -119,10 +154,31 @@ func (n *Nfa[S, V]) NewState() *State[S, V] {
 	}
 }
 
+// MarkShortest marks the accepting state s as the end of the matching of its pattern: once s is reached, the states
+// from which s can be reached (except the start state) aren't followed anymore. This turns the pattern of s into a
+// non-greedy pattern, which only matches its shortest match.
+// Panics if s isn't accepting.
+func (n *Nfa[S, V]) MarkShortest(s *State[S, V]) {
+	if !s.IsAccepting() {
+		panic("MarkShortest: state is NOT accepting")
+	}
+
+	if !s.shortest {
+		s.shortest = true
+		n.shortest = append(n.shortest, s)
+	}
+}
+
+// Shortest returns the accepting states that end the matching of their pattern (see [Nfa.MarkShortest]).
+func (n *Nfa[S, V]) Shortest() []*State[S, V] {
+	return slices.Clone(n.shortest)
+}
+
 // Returns a new accepting [State].
 func (n *Nfa[S, V]) newAcceptingState(value V) *State[S, V] {
 	id := n.nextStateID
 	n.nextStateID++
+	n.checkLimit(0)
 
 	state := &State[S, V]{
 		id:           id,
@@ -143,3 +199,10 @@ func (n *Nfa[S, V]) markAccepting(s *State[S, V], value V) {
 	s.acceptIdx = idx
 	s.value = value
 }
+
+// Records [ErrStateLimit] if adding count more states to the nfa would exceed its limit.
+func (n *Nfa[S, V]) checkLimit(count int) {
+	if n.err == nil && n.stateLimit > 0 && n.nextStateID+count > n.stateLimit {
+		n.err = ErrStateLimit
+	}
+}
@@ -348,6 +348,28 @@ func TestNfa_BuildWithBracnhingOnTheSameSymbol(t *testing.T) {
 	})
 }
 
+// UT: Build an [nfa.Nfa] with transitions on different symbols from the same state.
+func TestNfa_BuildWithMultipleSymbols(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	machine := nfa.New[string, int]()
+	sState := machine.Start()
+	machine.Add(sState, "c")
+	machine.Add(sState, "a")
+	machine.AddAccepting(sState, "b", 10)
+	machine.Add(sState, "a")
+
+	// Act.
+	got, want := sState.OutgoingSymbols(), newSlice("c", "a", "b")
+
+	// Assert.
+	assert.EqualSf(t, got, want, "\n\n"+
+		"UT Name:  The outgoing symbols are returned in the order in which they're added.\n"+
+		"\033[32mExpected: %v.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", want, got)
+}
+
 // UT: Build an [nfa.Nfa] using a sequence.
 func TestNfa_BuildWithSequence(t *testing.T) {
 	t.Parallel() // Enable parallel execution.
@@ -883,6 +905,222 @@ func TestState_OutgoingFor(t *testing.T) {
 	})
 }
 
+// UT: Build an [nfa.Nfa] with a state limit.
+func TestNfa_StateLimit(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When the limit is NOT exceeded, NO error is reported.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		machine := nfa.New[string, int]()
+		machine.SetStateLimit(3)
+
+		// Act.
+		machine.Add(machine.Add(machine.Start(), "a"), "b")
+
+		// Assert.
+		got := machine.Err()
+
+		assert.Nilf(t, got, "\n\n"+
+			"UT Name:  When the limit is NOT exceeded, NO error is reported.\n"+
+			"\033[32mExpected: <nil>.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", got)
+	})
+
+	t.Run("When the limit is exceeded, an error is reported.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		machine := nfa.New[string, int]()
+		machine.SetStateLimit(3)
+
+		// Act.
+		machine.Add(machine.Add(machine.Add(machine.Start(), "a"), "b"), "c")
+
+		// Assert.
+		got := machine.Err()
+
+		assert.Errorf(t, got, nfa.ErrStateLimit, "\n\n"+
+			"UT Name:  When the limit is exceeded, an error is reported.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", nfa.ErrStateLimit, got)
+	})
+
+	t.Run("When reserving more states than the limit allows, an error is reported.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		machine := nfa.New[string, int]()
+		machine.SetStateLimit(10)
+
+		// Act.
+		got := machine.Reserve(10)
+
+		// Assert.
+		assert.Errorf(t, got, nfa.ErrStateLimit, "\n\n"+
+			"UT Name:  When reserving more states than the limit allows, an error is reported.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", nfa.ErrStateLimit, got)
+	})
+
+	t.Run("When there's NO limit, reserving states never reports an error.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		machine := nfa.New[string, int]()
+
+		// Act.
+		got := machine.Reserve(1_000_000_000)
+
+		// Assert.
+		assert.Nilf(t, got, "\n\n"+
+			"UT Name:  When there's NO limit, reserving states never reports an error.\n"+
+			"\033[32mExpected: <nil>.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", got)
+	})
+}
+
+// UT: Verify that all the transitions of an [nfa.State] are kept when adding more than 2 transitions.
+func TestState_OutgoingForWithManyTransitions(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	machine := nfa.New[string, int]()
+	sState := machine.Start()
+	tState := machine.NewState()
+
+	machine.Connect(sState, "a", tState)
+	machine.Connect(sState, "b", tState)
+	machine.Connect(sState, "c", tState)
+
+	for _, symbol := range newSlice("a", "b", "c") {
+		// Act.
+		got, want := sState.OutgoingFor(symbol), newSlice(tState)
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  When adding 3 transitions, each transition is kept.\n"+
+			"\033[32mExpected (symbol '%s'): %v.\033[0m\n"+
+			"\033[31mActual (symbol '%s'):   %v.\033[0m\n\n", symbol, want, symbol, got)
+	}
+}
+
+// UT: Verify that the outgoing symbols of an [nfa.State] are kept when adding more than 2 transitions.
+func TestState_OutgoingSymbolsWithManyTransitions(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	machine := nfa.New[string, int]()
+	sState := machine.Start()
+
+	machine.Add(sState, "a")
+	machine.Add(sState, "b")
+	machine.Add(sState, "c")
+	machine.Add(sState, "d")
+
+	// Act.
+	got, want := sState.OutgoingSymbols(), newSlice("a", "b", "c", "d")
+
+	// Assert.
+	assert.EqualSf(t, got, want, "\n\n"+
+		"UT Name:  When adding 4 transitions, each symbol is kept in the order in which it was added.\n"+
+		"\033[32mExpected: %v.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", want, got)
+}
+
+// UT: Verify that class based transitions of an [nfa.State] can be requested.
+func TestState_ClassTransitions(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	machine := nfa.New[string, int]()
+	sState := machine.Start()
+	vState := machine.AddClass(sState, vowels)
+	aState := machine.Add(sState, "a")
+
+	t.Run("For a 'State' with a class transition, the 'OutgoingFor' operation returns the class target.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Act.
+		got, want := sState.OutgoingFor("e"), newSlice(vState)
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  For a 'State' with a class transition, the 'OutgoingFor' operation returns the class target.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("For a 'State' with a class and symbol transition, the 'OutgoingFor' operation returns both targets.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Act.
+		got, want := sState.OutgoingFor("a"), newSlice(aState, vState)
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  For a 'State' with a class and symbol transition, the 'OutgoingFor' operation returns both targets.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("For a 'State' with a class transition, the 'OutgoingFor' operation returns <nil> for a non-member.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Act.
+		got := sState.OutgoingFor("x")
+
+		// Assert.
+		assert.Nilf(t, got, "\n\n"+
+			"UT Name:  For a 'State' with a class transition, the 'OutgoingFor' operation returns <nil> for a non-member.\n"+
+			"\033[32mExpected: <nil>.\033[0m\n"+
+			"\033[31mActual:   NOT <nil>.\033[0m\n\n")
+	})
+
+	t.Run("The 'ClassTransitions' operation returns a copy.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Act: Mutate the returned value.
+		transitions := sState.ClassTransitions()
+		transitions[0].To = aState
+
+		// Assert: The mutation (see above) has NO effect on the 'State'.
+		got := sState.ClassTransitions()
+
+		assert.Truef(t, len(got) == 1 && got[0].To == vState, "\n\n"+
+			"UT Name:  The 'ClassTransitions' operation returns a copy.\n"+
+			"\033[32mExpected: 1 transition to the original 'State'.\033[0m\n"+
+			"\033[31mActual:   %d transition(s).\033[0m\n\n", len(got))
+	})
+}
+
+// UT: Verify the anchor based transitions of an [nfa.State].
+func TestState_AnchorTransitions(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	machine := nfa.New[string, int]()
+	sState := machine.Start()
+	lState := machine.AddAnchor(sState, nfa.AnchorLineStart)
+
+	// Act.
+	transitions := sState.AnchorTransitions()
+	transitions[0].To = sState
+
+	// Assert.
+	got := sState.AnchorTransitions()
+
+	assert.Truef(t, len(got) == 1 && got[0].To == lState && got[0].Anchor == nfa.AnchorLineStart, "\n\n"+
+		"UT Name:  The 'AnchorTransitions' operation returns a copy of the anchor based transitions.\n"+
+		"\033[32mExpected: 1 transition on 'AnchorLineStart' to the original 'State'.\033[0m\n"+
+		"\033[31mActual:   %d transition(s).\033[0m\n\n", len(got))
+
+	assert.IsEmptyf(t, sState.OutgoingSymbols(), "\n\n"+
+		"UT Name:  An anchor based transition doesn't consume a symbol.\n"+
+		"\033[32mExpected: 0.\033[0m\n"+
+		"\033[31mActual:   %d.\033[0m\n\n", len(sState.OutgoingSymbols()))
+}
+
 // UT: Verify that the accept value of an [nfa.State] is correct.
 func TestState_AcceptValue(t *testing.T) {
 	t.Parallel() // Enable parallel execution.
@@ -918,6 +1156,51 @@ func TestState_AcceptValue(t *testing.T) {
 	})
 }
 
+// UT: Mark a [nfa.State] that's NOT accepting as shortest.
+func TestNfa_MarkShortestPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	machine := nfa.New[string, int]()
+	state := machine.Add(machine.Start(), "a")
+
+	handler := func() {
+		machine.MarkShortest(state)
+	}
+
+	// Act / assert.
+	assert.Panicf(t, handler, "\n\n"+
+		"UT Name:  Marking a 'State' that's NOT accepting as shortest causes a panic.\n"+
+		"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+		"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+}
+
+// UT: Mark an accepting [nfa.State] as shortest.
+func TestNfa_MarkShortest(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	machine := nfa.New[string, int]()
+	aState := machine.AddAccepting(machine.Start(), "a", 10)
+	machine.AddAccepting(machine.Start(), "b", 20)
+
+	// Act.
+	machine.MarkShortest(aState)
+	machine.MarkShortest(aState)
+	got, want := machine.Shortest(), newSlice(aState)
+
+	// Assert.
+	assert.Truef(t, aState.IsShortest(), "\n\n"+
+		"UT Name:  When marking a 'State' as shortest, the 'IsShortest' operation returns true.\n"+
+		"\033[32mExpected: true.\033[0m\n"+
+		"\033[31mActual:   false.\033[0m\n\n")
+
+	assert.EqualSf(t, got, want, "\n\n"+
+		"UT Name:  When marking a 'State' as shortest (twice), the 'Shortest' operation returns it once.\n"+
+		"\033[32mExpected: %v.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", want, got)
+}
+
 // Utility: Return a slice of T, containing args.
 func newSlice[T any](args ...T) []T {
 	container := make([]T, len(args))
@@ -926,6 +1209,17 @@ func newSlice[T any](args ...T) []T {
 	return container
 }
 
+// A class containing the vowels "a", "e", "i", "o" and "u".
+var vowels = &vowelClass{}
+
+// An [nfa.Class] containing the vowels "a", "e", "i", "o" and "u".
+type vowelClass struct{}
+
+// Contains reports whether sym is a vowel.
+func (*vowelClass) Contains(sym string) bool {
+	return sym == "a" || sym == "e" || sym == "i" || sym == "o" || sym == "u"
+}
+
 var benchmarkOutput int // Output of the benchmark(s). Used to avoid compiler optimizations.
 
 // Benchmark(s): Build a chain of transitions in an [nfa.Nfa].
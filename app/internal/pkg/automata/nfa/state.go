@@ -30,12 +30,15 @@ import "github.com/kdeconinck/align/internal/pkg/collections/mvmap"
 
 // State is a node in an [Nfa].
 type State[S comparable, V any] struct {
-	id           int
-	edge         edge[S, V]                    // Fast path. Used when there's only a single transition.
-	transitions  *mvmap.MvMap[S, *State[S, V]] // Slow path: Used when there are multiple transitions.
-	eTransitions []*State[S, V]
-	acceptIdx    int
-	value        V // The accepting value (if any).
+	id                int
+	edge              edge[S, V]                    // Fast path. Used when there's only a single transition.
+	transitions       *mvmap.MvMap[S, *State[S, V]] // Slow path: Used when there are multiple transitions.
+	classTransitions  []ClassTransition[S, V]       // Transitions on a set of symbols (see [Class]).
+	anchorTransitions []AnchorTransition[S, V]      // Transitions that only apply where an [Anchor] holds.
+	eTransitions      []*State[S, V]
+	acceptIdx         int
+	value             V    // The accepting value (if any).
+	shortest          bool // Indicates whether the (accepting) state ends the matching of its pattern.
 }
 
 // An 'edge' is a "single" transition from on [State] to another.
@@ -73,7 +76,7 @@ func (s *State[S, V]) ID() int {
 }
 
 // OutgoingSymbols returns all the symbols that have at least one outgoing transition from this state.
-// Note: The order is undefined.
+// The symbols are returned in the order in which their first transition was added.
 func (s *State[S, V]) OutgoingSymbols() []S {
 	if s.transitions == nil {
 		if !s.edge.has {
@@ -89,20 +92,27 @@ func (s *State[S, V]) OutgoingSymbols() []S {
 }
 
 // OutgoingFor returns all the [State]s reachable from the state by consuming symbol or nil if there are no transitions.
+// The result includes the targets of every [ClassTransition] whose [Class] contains symbol.
 func (s *State[S, V]) OutgoingFor(symbol S) []*State[S, V] {
+	var out []*State[S, V]
+
 	if s.transitions == nil {
 		if s.edge.has && s.edge.sym == symbol {
-			return []*State[S, V]{
+			out = []*State[S, V]{
 				s.edge.to,
 			}
 		}
-
-		return nil
+	} else {
+		symbolTransitions := s.transitions.Get(symbol)
+		out = make([]*State[S, V], len(symbolTransitions))
+		copy(out, symbolTransitions)
 	}
 
-	symbolTransitions := s.transitions.Get(symbol)
-	out := make([]*State[S, V], len(symbolTransitions))
-	copy(out, symbolTransitions)
+	for _, ct := range s.classTransitions {
+		if ct.Class.Contains(symbol) {
+			out = append(out, ct.To)
+		}
+	}
 
 	return out
 }
@@ -117,6 +127,12 @@ func (s *State[S, V]) IsAccepting() bool {
 	return s.AcceptIdx() > -1
 }
 
+// IsShortest reports whether the state is an accepting state that ends the matching of its pattern (see
+// [Nfa.MarkShortest]).
+func (s *State[S, V]) IsShortest() bool {
+	return s.shortest
+}
+
 // AcceptValue returns the accepting value if the state is accepting or the zero value of V if the state is NOT
 // accepting.
 func (s *State[S, V]) AcceptValue() V {
@@ -130,9 +146,10 @@ func (s *State[S, V]) AcceptValue() V {
 }
 
 // Add a transition from s on sym to to.
-// When there's NO edge on s, the transition is added as an [edge] (fast path) instead of using the [mvmap.MvMap].
+// When s has NO transitions yet, the transition is added as an [edge] (fast path) instead of using the [mvmap.MvMap].
+// Once the [mvmap.MvMap] is in use, it holds all the transitions (the edge is never used again).
 func (s *State[S, V]) put(sym S, to *State[S, V]) {
-	if !s.edge.has {
+	if !s.edge.has && s.transitions == nil {
 		s.edge = newEdge(sym, to)
 
 		return
@@ -140,7 +157,7 @@ func (s *State[S, V]) put(sym S, to *State[S, V]) {
 
 	if s.edge.has {
 		if s.transitions == nil {
-			s.transitions = mvmap.New[S, *State[S, V]]()
+			s.transitions = mvmap.NewOrdered[S, *State[S, V]]()
 		}
 
 		s.transitions.Put(s.edge.sym, s.edge.to)
@@ -0,0 +1,246 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package deque implements a generic double-ended queue, backed by a growable ring buffer.
+package deque
+
+import "iter"
+
+// The capacity of a [Deque] the first time it grows.
+const minCapacity = 8
+
+// Deque is a double-ended queue, containing elements of type T.
+// Elements are pushed and popped at both ends in (amortized) O(1), and accessed by index in O(1).
+// A Deque that's bounded (see [Bounded]) evicts an element at the opposite end when an element is pushed while it's
+// full.
+type Deque[T any] struct {
+	data  []T // The ring buffer, which holds the elements from index head (wrapping around at its end).
+	head  int // The index in data of the front element.
+	len   int // The amount of elements.
+	limit int // The maximum amount of elements (0 if there's no maximum).
+}
+
+// New returns an empty [Deque].
+func New[T any]() *Deque[T] {
+	return &Deque[T]{}
+}
+
+// WithCapacity returns an empty [Deque] that has capacity of cap.
+func WithCapacity[T any](cap int) *Deque[T] {
+	return &Deque[T]{
+		data: make([]T, cap),
+	}
+}
+
+// Bounded returns an empty [Deque] that contains at most limit elements.
+// Pushing an element while the deque is full evicts the element at the opposite end.
+// Panics if limit is less than 1.
+func Bounded[T any](limit int) *Deque[T] {
+	if limit < 1 {
+		panic("Bounded: limit must be at least 1")
+	}
+
+	return &Deque[T]{
+		limit: limit,
+	}
+}
+
+// Len returns the amount of elements in the deque.
+func (d *Deque[T]) Len() int {
+	return d.len
+}
+
+// Cap returns the amount of elements that the deque can hold without growing.
+func (d *Deque[T]) Cap() int {
+	return len(d.data)
+}
+
+// PushBack pushes v at the back of the deque.
+// If the deque is bounded and full, the front element is evicted. It returns the evicted element and true if an
+// element was evicted.
+func (d *Deque[T]) PushBack(v T) (evicted T, ok bool) {
+	if d.len == d.limit && d.limit > 0 {
+		evicted, ok = d.PopFront()
+	}
+
+	d.grow()
+	d.data[d.index(d.len)] = v
+	d.len++
+
+	return evicted, ok
+}
+
+// PushFront pushes v at the front of the deque.
+// If the deque is bounded and full, the back element is evicted. It returns the evicted element and true if an
+// element was evicted.
+func (d *Deque[T]) PushFront(v T) (evicted T, ok bool) {
+	if d.len == d.limit && d.limit > 0 {
+		evicted, ok = d.PopBack()
+	}
+
+	d.grow()
+	d.head = d.index(len(d.data) - 1)
+	d.data[d.head] = v
+	d.len++
+
+	return evicted, ok
+}
+
+// PopFront pops from the front of the deque.
+// It returns the popped element and true if an element was popped.
+// If the deque contains no elements to pop, the second return value is false.
+func (d *Deque[T]) PopFront() (v T, ok bool) {
+	if d.len == 0 {
+		return v, false
+	}
+
+	var zero T
+
+	v, d.data[d.head] = d.data[d.head], zero
+	d.head = d.index(1)
+	d.len--
+
+	return v, true
+}
+
+// PopBack pops from the back of the deque.
+// It returns the popped element and true if an element was popped.
+// If the deque contains no elements to pop, the second return value is false.
+func (d *Deque[T]) PopBack() (v T, ok bool) {
+	if d.len == 0 {
+		return v, false
+	}
+
+	var zero T
+
+	idx := d.index(d.len - 1)
+	v, d.data[idx] = d.data[idx], zero
+	d.len--
+
+	return v, true
+}
+
+// PeekFront returns the front element of the deque, without popping it.
+// If the deque contains no elements, the second return value is false.
+func (d *Deque[T]) PeekFront() (v T, ok bool) {
+	if d.len == 0 {
+		return v, false
+	}
+
+	return d.data[d.head], true
+}
+
+// PeekBack returns the back element of the deque, without popping it.
+// If the deque contains no elements, the second return value is false.
+func (d *Deque[T]) PeekBack() (v T, ok bool) {
+	if d.len == 0 {
+		return v, false
+	}
+
+	return d.data[d.index(d.len-1)], true
+}
+
+// At returns the element at index i, where the front element is at index 0.
+// Panics if i is out of range.
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.len {
+		panic("At: index out of range")
+	}
+
+	return d.data[d.index(i)]
+}
+
+// Clear removes all the elements from the deque, keeping its capacity.
+func (d *Deque[T]) Clear() {
+	for i := range d.len {
+		var zero T
+
+		d.data[d.index(i)] = zero
+	}
+
+	d.head, d.len = 0, 0
+}
+
+// All returns an iterator over the indexes and elements of the deque, from front to back.
+// The deque must NOT be modified during the iteration.
+func (d *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range d.len {
+			if !yield(i, d.data[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the indexes and elements of the deque, from back to front.
+// The deque must NOT be modified during the iteration.
+func (d *Deque[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := d.len - 1; i >= 0; i-- {
+			if !yield(i, d.data[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Returns the index in the ring buffer of the element at index i (which may be negative or past the end of the ring
+// buffer by at most its size).
+func (d *Deque[T]) index(i int) int {
+	idx := d.head + i
+
+	switch {
+	case idx >= len(d.data):
+		return idx - len(d.data)
+
+	case idx < 0:
+		return idx + len(d.data)
+
+	default:
+		return idx
+	}
+}
+
+// Ensures that the ring buffer can hold another element.
+// The capacity is doubled (but never beyond the limit of a bounded deque), and the elements are moved to the start
+// of the new ring buffer.
+func (d *Deque[T]) grow() {
+	if d.len < len(d.data) {
+		return
+	}
+
+	capacity := max(2*len(d.data), minCapacity)
+
+	if d.limit > 0 {
+		capacity = min(capacity, d.limit)
+	}
+
+	data := make([]T, capacity)
+	n := copy(data, d.data[d.head:])
+	copy(data[n:], d.data[:d.head])
+
+	d.data, d.head = data, 0
+}
@@ -0,0 +1,625 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "deque" package.
+package deque_test
+
+import (
+	"iter"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/collections/deque"
+	"github.com/kdeconinck/align/internal/pkg/collections/queue"
+)
+
+// UT: Create a new [deque.Deque].
+func TestNew(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	d := deque.New[int]()
+
+	// Act.
+	got, want := d.Len(), 0
+
+	// Assert.
+	assert.Equalf(t, got, want, "\n\n"+
+		"UT Name:  When creating a new 'Deque', it contains NO elements.\n"+
+		"\033[32mExpected: %d.\033[0m\n"+
+		"\033[31mActual:   %d.\033[0m\n\n", want, got)
+}
+
+// UT: Create a new [deque.Deque] with a specific capacity.
+func TestWithCapacity(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	d := deque.WithCapacity[int](10)
+
+	// Act.
+	got, want := d.Cap(), 10
+
+	// Assert.
+	assert.Equalf(t, got, want, "\n\n"+
+		"UT Name:  When creating a new 'Deque' with a capacity, it has that capacity.\n"+
+		"\033[32mExpected: %d.\033[0m\n"+
+		"\033[31mActual:   %d.\033[0m\n\n", want, got)
+}
+
+// UT: Create a new bounded [deque.Deque] with an invalid limit.
+func TestBoundedPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	handler := func() {
+		deque.Bounded[int](0)
+	}
+
+	// Act / assert.
+	assert.Panicf(t, handler, "\n\n"+
+		"UT Name:  Creating a bounded 'Deque' with a limit less than 1 causes a panic.\n"+
+		"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+		"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+}
+
+// UT: Push and pop elements at both ends of a [deque.Deque].
+func TestDeque_PushPop(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When popping from an empty deque, false is returned.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		d := deque.New[int]()
+
+		// Act.
+		_, okFront := d.PopFront()
+		_, okBack := d.PopBack()
+		got := okFront || okBack
+
+		// Assert.
+		assert.Falsef(t, got, "\n\n"+
+			"UT Name:  When popping from an empty deque, false is returned.\n"+
+			"\033[32mExpected: false.\033[0m\n"+
+			"\033[31mActual:   %t.\033[0m\n\n", got)
+	})
+
+	t.Run("When pushing at both ends, the elements are popped in order.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		d := deque.New[int]()
+
+		for idx := range 20 {
+			d.PushBack(idx)
+			d.PushFront(-idx - 1)
+		}
+
+		// Act.
+		var got []int
+
+		for v, ok := d.PopFront(); ok; v, ok = d.PopFront() {
+			got = append(got, v)
+
+			if v, ok := d.PopBack(); ok {
+				got = append(got, v)
+			}
+		}
+
+		// Assert.
+		var want []int
+
+		for idx := range 20 {
+			want = append(want, -20+idx, 19-idx)
+		}
+
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  When pushing at both ends, the elements are popped in order.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+}
+
+// UT: Peek at both ends of a [deque.Deque].
+func TestDeque_Peek(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When peeking into an empty deque, false is returned.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		d := deque.New[int]()
+
+		// Act.
+		_, okFront := d.PeekFront()
+		_, okBack := d.PeekBack()
+		got := okFront || okBack
+
+		// Assert.
+		assert.Falsef(t, got, "\n\n"+
+			"UT Name:  When peeking into an empty deque, false is returned.\n"+
+			"\033[32mExpected: false.\033[0m\n"+
+			"\033[31mActual:   %t.\033[0m\n\n", got)
+	})
+
+	t.Run("When peeking, the elements at both ends are returned without removing them.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		d := deque.New[int]()
+		d.PushBack(2)
+		d.PushBack(3)
+		d.PushFront(1)
+
+		// Act.
+		front, _ := d.PeekFront()
+		back, _ := d.PeekBack()
+		got := []int{front, back, d.Len()}
+
+		// Assert.
+		want := []int{1, 3, 3}
+
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  When peeking, the elements at both ends are returned without removing them.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+}
+
+// UT: Get the element at an index of a [deque.Deque].
+func TestDeque_At(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When the index is in range, the element at the index is returned.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		d := deque.WithCapacity[string](4)
+		d.PushBack("c")
+		d.PushBack("d")
+		d.PushFront("b")
+		d.PushFront("a")
+
+		// Act.
+		got := []string{d.At(0), d.At(1), d.At(2), d.At(3)}
+
+		// Assert.
+		want := []string{"a", "b", "c", "d"}
+
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  When the index is in range, the element at the index is returned.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	for tcName, tc := range map[string]struct {
+		idx int
+	}{
+		"Getting the element at a negative index causes a panic.":             {idx: -1},
+		"Getting the element at an index past the end causes a panic.":        {idx: 2},
+		"Getting the element at an index within the capacity causes a panic.": {idx: 3},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			d := deque.WithCapacity[int](4)
+			d.PushBack(1)
+			d.PushBack(2)
+
+			handler := func() {
+				d.At(tc.idx)
+			}
+
+			// Act / assert.
+			assert.Panicf(t, handler, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+				"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n", tcName)
+		})
+	}
+}
+
+// UT: Push elements into a bounded [deque.Deque].
+func TestDeque_Bounded(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When pushing at the back of a full deque, the front element is evicted.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		d := deque.Bounded[int](3)
+		d.PushBack(1)
+		d.PushBack(2)
+		d.PushBack(3)
+
+		// Act.
+		evicted, ok := d.PushBack(4)
+		got := append([]int{evicted, d.Len(), d.Cap()}, slices.Collect(values(d))...)
+
+		// Assert.
+		want := []int{1, 3, 3, 2, 3, 4}
+
+		assert.Truef(t, ok, "\n\n"+
+			"UT Name:  When pushing at the back of a full deque, true is returned.\n"+
+			"\033[32mExpected: true.\033[0m\n"+
+			"\033[31mActual:   %t.\033[0m\n\n", ok)
+
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  When pushing at the back of a full deque, the front element is evicted.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("When pushing at the front of a full deque, the back element is evicted.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		d := deque.Bounded[int](3)
+		d.PushBack(1)
+		d.PushBack(2)
+		d.PushBack(3)
+
+		// Act.
+		evicted, _ := d.PushFront(0)
+		got := append([]int{evicted}, slices.Collect(values(d))...)
+
+		// Assert.
+		want := []int{3, 0, 1, 2}
+
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  When pushing at the front of a full deque, the back element is evicted.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("When pushing into a deque that isn't full, NO element is evicted.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		d := deque.Bounded[int](3)
+		d.PushBack(1)
+
+		// Act.
+		_, got := d.PushFront(0)
+
+		// Assert.
+		assert.Falsef(t, got, "\n\n"+
+			"UT Name:  When pushing into a deque that isn't full, NO element is evicted.\n"+
+			"\033[32mExpected: false.\033[0m\n"+
+			"\033[31mActual:   %t.\033[0m\n\n", got)
+	})
+}
+
+// UT: Clear a [deque.Deque].
+func TestDeque_Clear(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	d := deque.New[int]()
+
+	for idx := range 10 {
+		d.PushFront(idx)
+	}
+
+	// Act.
+	d.Clear()
+	d.PushBack(1)
+
+	got := slices.Collect(values(d))
+
+	// Assert.
+	want := []int{1}
+
+	assert.EqualSf(t, got, want, "\n\n"+
+		"UT Name:  When clearing a deque, all of its elements are removed.\n"+
+		"\033[32mExpected: %v.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", want, got)
+}
+
+// UT: Iterate over the elements of a [deque.Deque].
+func TestDeque_Iterators(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When iterating forward, the indexes and elements are yielded from front to back.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		d := newWrapped(5)
+
+		// Act.
+		var got []int
+
+		for idx, v := range d.All() {
+			got = append(got, idx, v)
+		}
+
+		// Assert.
+		want := []int{0, 0, 1, 1, 2, 2, 3, 3, 4, 4}
+
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  When iterating forward, the indexes and elements are yielded from front to back.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("When iterating backward, the indexes and elements are yielded from back to front.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		d := newWrapped(5)
+
+		// Act.
+		var got []int
+
+		for idx, v := range d.Backward() {
+			got = append(got, idx, v)
+		}
+
+		// Assert.
+		want := []int{4, 4, 3, 3, 2, 2, 1, 1, 0, 0}
+
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  When iterating backward, the indexes and elements are yielded from back to front.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("When the iteration stops early, NO more elements are yielded.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		d := newWrapped(5)
+
+		// Act.
+		var got []int
+
+		for _, v := range d.All() {
+			if v == 2 {
+				break
+			}
+
+			got = append(got, v)
+		}
+
+		for _, v := range d.Backward() {
+			if v == 2 {
+				break
+			}
+
+			got = append(got, v)
+		}
+
+		// Assert.
+		want := []int{0, 1, 4, 3}
+
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  When the iteration stops early, NO more elements are yielded.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+}
+
+// UT: Compare a [deque.Deque] with a slice, for a random sequence of operations.
+func TestDeque_Differential(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		limit int
+	}{
+		"An unbounded deque behaves like a slice.":                                 {limit: 0},
+		"A bounded deque behaves like a slice that's trimmed at the opposite end.": {limit: 7},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			rnd := rand.New(rand.NewSource(46))
+			d := deque.New[int]()
+
+			if tc.limit > 0 {
+				d = deque.Bounded[int](tc.limit)
+			}
+
+			var model []int
+
+			for step := range 5_000 {
+				// Act.
+				switch rnd.Intn(5) {
+				case 0, 1:
+					d.PushBack(step)
+					model = append(model, step)
+
+					if tc.limit > 0 && len(model) > tc.limit {
+						model = model[1:]
+					}
+
+				case 2:
+					d.PushFront(step)
+					model = append([]int{step}, model...)
+
+					if tc.limit > 0 && len(model) > tc.limit {
+						model = model[:len(model)-1]
+					}
+
+				case 3:
+					d.PopFront()
+
+					if len(model) > 0 {
+						model = model[1:]
+					}
+
+				default:
+					d.PopBack()
+
+					if len(model) > 0 {
+						model = model[:len(model)-1]
+					}
+				}
+
+				got := make([]int, d.Len())
+
+				for idx := range got {
+					got[idx] = d.At(idx)
+				}
+
+				// Assert.
+				assert.EqualSf(t, got, model, "\n\n"+
+					"UT Name:  %s\n"+
+					"\033[32mExpected: %v.\033[0m\n"+
+					"\033[31mActual:   %v.\033[0m\n\n", tcName, model, got)
+			}
+		})
+	}
+}
+
+// Returns a [deque.Deque] that contains the elements 0 to count (exclusive), wrapped around the end of its ring buffer.
+func newWrapped(count int) *deque.Deque[int] {
+	d := deque.WithCapacity[int](count)
+
+	for idx := count / 2; idx < count; idx++ {
+		d.PushBack(idx)
+	}
+
+	for idx := count/2 - 1; idx >= 0; idx-- {
+		d.PushFront(idx)
+	}
+
+	return d
+}
+
+// Returns an iterator over the elements of d, from front to back.
+func values[T any](d *deque.Deque[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range d.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+var benchmarkOutput int // Output of the benchmark(s). Used to avoid compiler optimizations.
+
+// Benchmark(s): Push elements at the back of a [deque.Deque], and pop them from the front.
+func BenchmarkDeque_1(b *testing.B)       { benchmarkDeque(1, b) }
+func BenchmarkDeque_10(b *testing.B)      { benchmarkDeque(10, b) }
+func BenchmarkDeque_100(b *testing.B)     { benchmarkDeque(100, b) }
+func BenchmarkDeque_1000(b *testing.B)    { benchmarkDeque(1_000, b) }
+func BenchmarkDeque_1000000(b *testing.B) { benchmarkDeque(1_000_000, b) }
+
+// Benchmark(s): Enqueue elements in a [queue.Queue], and dequeue them.
+func BenchmarkQueue_1(b *testing.B)       { benchmarkQueue(1, b) }
+func BenchmarkQueue_10(b *testing.B)      { benchmarkQueue(10, b) }
+func BenchmarkQueue_100(b *testing.B)     { benchmarkQueue(100, b) }
+func BenchmarkQueue_1000(b *testing.B)    { benchmarkQueue(1_000, b) }
+func BenchmarkQueue_1000000(b *testing.B) { benchmarkQueue(1_000_000, b) }
+
+// Benchmark(s): Push and pop elements of a [deque.Deque] that's used as a sliding window (the way a scanner keeps the
+// symbols it may unread).
+func BenchmarkDequeWindow_16(b *testing.B)   { benchmarkDequeWindow(16, b) }
+func BenchmarkDequeWindow_4096(b *testing.B) { benchmarkDequeWindow(4_096, b) }
+
+// Benchmark(s): Enqueue and dequeue elements of a [queue.Queue] that's used as a sliding window.
+func BenchmarkQueueWindow_16(b *testing.B)   { benchmarkQueueWindow(16, b) }
+func BenchmarkQueueWindow_4096(b *testing.B) { benchmarkQueueWindow(4_096, b) }
+
+// Benchmark: Measure the performance of pushing elements at the back of a [deque.Deque], and popping them from the
+// front.
+// Parameters:
+// - count: The amount of elements to push.
+// - b:     The [testing.B] instance.
+func benchmarkDeque(count int, b *testing.B) {
+	for b.Loop() {
+		d := deque.New[int]()
+
+		for idx := range count {
+			d.PushBack(idx)
+		}
+
+		for range count {
+			benchmarkOutput, _ = d.PopFront()
+		}
+	}
+}
+
+// Benchmark: Measure the performance of enqueuing elements in a [queue.Queue], and dequeuing them.
+// Parameters:
+// - count: The amount of elements to enqueue.
+// - b:     The [testing.B] instance.
+func benchmarkQueue(count int, b *testing.B) {
+	for b.Loop() {
+		q := queue.New[int]()
+
+		for idx := range count {
+			q.Enqueue(idx)
+		}
+
+		for range count {
+			benchmarkOutput, _ = q.Dequeue()
+		}
+	}
+}
+
+// Benchmark: Measure the performance of a [deque.Deque] that holds a sliding window of elements.
+// Parameters:
+// - size: The amount of elements in the window.
+// - b:    The [testing.B] instance.
+func benchmarkDequeWindow(size int, b *testing.B) {
+	d := deque.New[int]()
+
+	for idx := range size {
+		d.PushBack(idx)
+	}
+
+	for b.Loop() {
+		d.PushBack(0)
+		benchmarkOutput, _ = d.PopFront()
+	}
+}
+
+// Benchmark: Measure the performance of a [queue.Queue] that holds a sliding window of elements.
+// Parameters:
+// - size: The amount of elements in the window.
+// - b:    The [testing.B] instance.
+func benchmarkQueueWindow(size int, b *testing.B) {
+	q := queue.New[int]()
+
+	for idx := range size {
+		q.Enqueue(idx)
+	}
+
+	for b.Loop() {
+		q.Enqueue(0)
+		benchmarkOutput, _ = q.Dequeue()
+	}
+}
@@ -0,0 +1,234 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package intervalmap implements a generic map from disjoint intervals of ordered keys to values.
+package intervalmap
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Interval is the half-open interval [Lo, Hi): it contains the keys that are at least Lo and less than Hi.
+// Half-open intervals can be split and joined without knowing the successor of a key, which works for any ordered type
+// (the interval of the runes 'a' to 'z' is ['a', 'z'+1)).
+type Interval[K cmp.Ordered] struct {
+	// Lo is the first key in the interval.
+	Lo K
+
+	// Hi is the first key after the interval.
+	Hi K
+}
+
+// IsEmpty reports whether the interval doesn't contain any keys.
+func (i Interval[K]) IsEmpty() bool {
+	return i.Lo >= i.Hi
+}
+
+// Contains reports whether k is part of the interval.
+func (i Interval[K]) Contains(k K) bool {
+	return i.Lo <= k && k < i.Hi
+}
+
+// Overlaps reports whether the interval and other have at least one key in common.
+func (i Interval[K]) Overlaps(other Interval[K]) bool {
+	return i.Lo < other.Hi && other.Lo < i.Hi && !i.IsEmpty() && !other.IsEmpty()
+}
+
+// Map maps disjoint intervals of keys of type K to values of type V.
+// Inserting an interval splits the intervals that it partly covers, and adjacent intervals with the same value are
+// merged. As a result, the intervals of a map are always the fewest intervals that describe it.
+type Map[K cmp.Ordered, V comparable] struct {
+	entries []entry[K, V] // The intervals (which are NOT empty) and their values, ordered by key.
+}
+
+// An interval and the value that its keys are mapped to.
+type entry[K cmp.Ordered, V comparable] struct {
+	interval Interval[K]
+	value    V
+}
+
+// New returns an empty [Map].
+func New[K cmp.Ordered, V comparable]() *Map[K, V] {
+	return &Map[K, V]{}
+}
+
+// Len returns the amount of intervals in the map.
+func (m *Map[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Insert maps the keys in [lo, hi) to v, replacing the value of the keys that were already mapped.
+// Inserting an empty interval doesn't change the map.
+// Panics if hi is less than lo.
+func (m *Map[K, V]) Insert(lo, hi K, v V) {
+	if hi < lo {
+		panic("Insert: interval ends before it starts")
+	}
+
+	m.replace(Interval[K]{Lo: lo, Hi: hi}, []entry[K, V]{{interval: Interval[K]{Lo: lo, Hi: hi}, value: v}})
+}
+
+// Delete removes the keys in [lo, hi) from the map.
+// Panics if hi is less than lo.
+func (m *Map[K, V]) Delete(lo, hi K) {
+	if hi < lo {
+		panic("Delete: interval ends before it starts")
+	}
+
+	m.replace(Interval[K]{Lo: lo, Hi: hi}, nil)
+}
+
+// Get returns the value that k is mapped to.
+// If k isn't mapped, the second return value is false.
+func (m *Map[K, V]) Get(k K) (v V, ok bool) {
+	idx := m.search(k)
+
+	if idx == len(m.entries) || !m.entries[idx].interval.Contains(k) {
+		return v, false
+	}
+
+	return m.entries[idx].value, true
+}
+
+// All returns an iterator over the intervals of the map and their values, ordered by key.
+// The map must NOT be modified during the iteration.
+func (m *Map[K, V]) All() iter.Seq2[Interval[K], V] {
+	return func(yield func(Interval[K], V) bool) {
+		for _, e := range m.entries {
+			if !yield(e.interval, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Overlapping returns an iterator over the parts of the intervals of the map that overlap [lo, hi), and their values,
+// ordered by key. Each interval is clipped to [lo, hi).
+// The map must NOT be modified during the iteration.
+func (m *Map[K, V]) Overlapping(lo, hi K) iter.Seq2[Interval[K], V] {
+	return func(yield func(Interval[K], V) bool) {
+		if lo >= hi {
+			return
+		}
+
+		for idx := m.search(lo); idx < len(m.entries) && m.entries[idx].interval.Lo < hi; idx++ {
+			e := m.entries[idx]
+
+			if !yield(Interval[K]{Lo: max(e.interval.Lo, lo), Hi: min(e.interval.Hi, hi)}, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Complement returns an iterator over the intervals of the keys in [lo, hi) that aren't mapped, ordered by key.
+// The map must NOT be modified during the iteration.
+func (m *Map[K, V]) Complement(lo, hi K) iter.Seq[Interval[K]] {
+	return func(yield func(Interval[K]) bool) {
+		next := lo // The first key that might not be mapped.
+
+		for covered := range m.Overlapping(lo, hi) {
+			if next < covered.Lo && !yield(Interval[K]{Lo: next, Hi: covered.Lo}) {
+				return
+			}
+
+			next = covered.Hi
+		}
+
+		if next < hi {
+			yield(Interval[K]{Lo: next, Hi: hi})
+		}
+	}
+}
+
+// Returns the index of the first entry that ends after k (or the amount of entries if there's none).
+func (m *Map[K, V]) search(k K) int {
+	idx, _ := slices.BinarySearchFunc(m.entries, k, func(e entry[K, V], k K) int {
+		if e.interval.Hi <= k {
+			return -1
+		}
+
+		return 1
+	})
+
+	return idx
+}
+
+// Replaces the entries within interval by entries (which must be part of interval, and ordered by key).
+// The entries that are partly covered by interval are split, and the adjacent entries with the same value are merged.
+func (m *Map[K, V]) replace(interval Interval[K], entries []entry[K, V]) {
+	if interval.IsEmpty() {
+		return
+	}
+
+	from, to := m.search(interval.Lo), m.search(interval.Hi)
+
+	if to < len(m.entries) && m.entries[to].interval.Lo < interval.Hi {
+		to++ // The entry that contains interval.Hi is split as well.
+	}
+
+	var replacement []entry[K, V]
+
+	if from < to && m.entries[from].interval.Lo < interval.Lo {
+		left := m.entries[from]
+		left.interval.Hi = interval.Lo
+		replacement = append(replacement, left)
+	}
+
+	replacement = append(replacement, entries...)
+
+	if from < to && m.entries[to-1].interval.Hi > interval.Hi {
+		right := m.entries[to-1]
+		right.interval.Lo = interval.Hi
+		replacement = append(replacement, right)
+	}
+
+	m.entries = slices.Replace(m.entries, from, to, replacement...)
+	m.merge(max(from-1, 0), min(from+len(replacement)+1, len(m.entries)))
+}
+
+// Merges the adjacent entries in the range [from, to) that have the same value.
+func (m *Map[K, V]) merge(from, to int) {
+	kept := from
+
+	for idx := from + 1; idx < to; idx++ {
+		last, e := &m.entries[kept], m.entries[idx]
+
+		if last.interval.Hi == e.interval.Lo && last.value == e.value {
+			last.interval.Hi = e.interval.Hi
+
+			continue
+		}
+
+		kept++
+		m.entries[kept] = e
+	}
+
+	if to > from {
+		m.entries = slices.Delete(m.entries, kept+1, to)
+	}
+}
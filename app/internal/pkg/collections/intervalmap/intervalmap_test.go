@@ -0,0 +1,446 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "intervalmap" package.
+package intervalmap_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/collections/intervalmap"
+)
+
+// UT: Verify the relation between [intervalmap.Interval]s and keys.
+func TestInterval(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		got, want bool
+	}{
+		"An interval that ends where it starts is empty.": {
+			got: intervalmap.Interval[int]{Lo: 1, Hi: 1}.IsEmpty(), want: true,
+		},
+		"An interval contains its first key.": {
+			got: intervalmap.Interval[int]{Lo: 1, Hi: 3}.Contains(1), want: true,
+		},
+		"An interval does NOT contain the key where it ends.": {
+			got: intervalmap.Interval[int]{Lo: 1, Hi: 3}.Contains(3), want: false,
+		},
+		"Intervals that share keys overlap.": {
+			got:  intervalmap.Interval[int]{Lo: 1, Hi: 3}.Overlaps(intervalmap.Interval[int]{Lo: 2, Hi: 5}),
+			want: true,
+		},
+		"Adjacent intervals don't overlap.": {
+			got:  intervalmap.Interval[int]{Lo: 1, Hi: 3}.Overlaps(intervalmap.Interval[int]{Lo: 3, Hi: 5}),
+			want: false,
+		},
+		"An empty interval doesn't overlap any interval.": {
+			got:  intervalmap.Interval[int]{Lo: 2, Hi: 2}.Overlaps(intervalmap.Interval[int]{Lo: 1, Hi: 5}),
+			want: false,
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Assert.
+			assert.Equalf(t, tc.got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %t.\033[0m\n"+
+				"\033[31mActual:   %t.\033[0m\n\n", tcName, tc.want, tc.got)
+		})
+	}
+}
+
+// UT: Insert intervals into an [intervalmap.Map].
+func TestMap_Insert(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		inserts [][3]int // The lo, hi and value of each insert.
+		want    []string
+	}{
+		"Inserting disjoint intervals keeps them ordered by key.": {
+			inserts: [][3]int{{10, 20, 1}, {0, 5, 2}},
+			want:    []string{"[0, 5)=2", "[10, 20)=1"},
+		},
+		"Inserting an interval in the middle of another one splits it.": {
+			inserts: [][3]int{{0, 10, 1}, {3, 5, 2}},
+			want:    []string{"[0, 3)=1", "[3, 5)=2", "[5, 10)=1"},
+		},
+		"Inserting an interval that covers other ones replaces them.": {
+			inserts: [][3]int{{0, 2, 1}, {3, 5, 2}, {6, 8, 3}, {1, 7, 4}},
+			want:    []string{"[0, 1)=1", "[1, 7)=4", "[7, 8)=3"},
+		},
+		"Inserting an interval next to one with the same value merges them.": {
+			inserts: [][3]int{{0, 5, 1}, {10, 15, 1}, {5, 10, 1}},
+			want:    []string{"[0, 15)=1"},
+		},
+		"Inserting an interval next to one with another value doesn't merge them.": {
+			inserts: [][3]int{{0, 5, 1}, {5, 10, 2}},
+			want:    []string{"[0, 5)=1", "[5, 10)=2"},
+		},
+		"Inserting an empty interval doesn't change the map.": {
+			inserts: [][3]int{{0, 5, 1}, {3, 3, 2}},
+			want:    []string{"[0, 5)=1"},
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			m := intervalmap.New[int, int]()
+
+			// Act.
+			for _, insert := range tc.inserts {
+				m.Insert(insert[0], insert[1], insert[2])
+			}
+
+			got := describe(m)
+
+			// Assert.
+			assert.EqualSf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+
+	t.Run("Inserting an interval that ends before it starts causes a panic.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		handler := func() {
+			intervalmap.New[int, int]().Insert(5, 4, 1)
+		}
+
+		// Act / assert.
+		assert.Panicf(t, handler, "\n\n"+
+			"UT Name:  Inserting an interval that ends before it starts causes a panic.\n"+
+			"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+			"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+	})
+}
+
+// UT: Delete intervals from an [intervalmap.Map].
+func TestMap_Delete(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("Deleting an interval splits the intervals that it partly covers.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		m := intervalmap.New[int, int]()
+		m.Insert(0, 10, 1)
+		m.Insert(10, 20, 2)
+
+		// Act.
+		m.Delete(5, 15)
+
+		got, want := describe(m), []string{"[0, 5)=1", "[15, 20)=2"}
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Deleting an interval splits the intervals that it partly covers.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Deleting an interval that ends before it starts causes a panic.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		handler := func() {
+			intervalmap.New[int, int]().Delete(5, 4)
+		}
+
+		// Act / assert.
+		assert.Panicf(t, handler, "\n\n"+
+			"UT Name:  Deleting an interval that ends before it starts causes a panic.\n"+
+			"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+			"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+	})
+}
+
+// UT: Look up keys in an [intervalmap.Map].
+func TestMap_Get(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	m := intervalmap.New[rune, string]()
+	m.Insert('a', 'z'+1, "lower")
+	m.Insert('0', '9'+1, "digit")
+
+	// Act.
+	var got []string
+
+	for _, r := range "a z 0 9 A" {
+		v, ok := m.Get(r)
+		got = append(got, fmt.Sprintf("%q=%s/%t", r, v, ok))
+	}
+
+	// Assert.
+	want := []string{
+		"'a'=lower/true", "' '=/false", "'z'=lower/true", "' '=/false", "'0'=digit/true", "' '=/false",
+		"'9'=digit/true", "' '=/false", "'A'=/false",
+	}
+
+	assert.EqualSf(t, got, want, "\n\n"+
+		"UT Name:  When looking up a key, the value of the interval that contains it is returned.\n"+
+		"\033[32mExpected: %v.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", want, got)
+}
+
+// UT: Query the intervals of an [intervalmap.Map] that overlap an interval.
+func TestMap_Overlapping(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	m := intervalmap.New[float64, string]()
+	m.Insert(0, 1, "a")
+	m.Insert(2, 3, "b")
+	m.Insert(4, 5, "c")
+
+	// Act.
+	var got []string
+
+	for interval, v := range m.Overlapping(0.5, 4) {
+		got = append(got, fmt.Sprintf("[%v, %v)=%s", interval.Lo, interval.Hi, v))
+	}
+
+	// Assert.
+	want := []string{"[0.5, 1)=a", "[2, 3)=b"}
+
+	assert.EqualSf(t, got, want, "\n\n"+
+		"UT Name:  When querying the overlapping intervals, they're clipped to the query.\n"+
+		"\033[32mExpected: %v.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", want, got)
+}
+
+// UT: Query the intervals of an [intervalmap.Map] that aren't mapped.
+func TestMap_Complement(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		lo, hi int
+		want   []string
+	}{
+		"The complement contains the gaps between the intervals.": {
+			lo: 0, hi: 20, want: []string{"[0, 2)", "[5, 8)", "[12, 20)"},
+		},
+		"The complement is limited to the bounds.": {
+			lo: 3, hi: 10, want: []string{"[5, 8)"},
+		},
+		"The complement of a covered interval is empty.": {
+			lo: 8, hi: 12, want: nil,
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			m := intervalmap.New[int, bool]()
+			m.Insert(2, 5, true)
+			m.Insert(8, 12, false)
+
+			// Act.
+			var got []string
+
+			for interval := range m.Complement(tc.lo, tc.hi) {
+				got = append(got, fmt.Sprintf("[%d, %d)", interval.Lo, interval.Hi))
+			}
+
+			// Assert.
+			assert.EqualSf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Compare an [intervalmap.Map] with a map of individual keys, for a random sequence of operations.
+func TestMap_Differential(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	const size = 64 // The keys are in [0, size).
+
+	// Arrange.
+	rnd := rand.New(rand.NewSource(48))
+	m := intervalmap.New[int, int]()
+	naive := make(map[int]int)
+
+	for step := range 2_000 {
+		lo := rnd.Intn(size)
+		hi := lo + rnd.Intn(size-lo+1)
+
+		// Act.
+		if rnd.Intn(4) == 0 {
+			m.Delete(lo, hi)
+
+			for k := lo; k < hi; k++ {
+				delete(naive, k)
+			}
+		} else {
+			v := rnd.Intn(3)
+			m.Insert(lo, hi, v)
+
+			for k := lo; k < hi; k++ {
+				naive[k] = v
+			}
+		}
+
+		// Assert.
+		got, want := describe(m), describeNaive(naive, 0, size)
+
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  After step %d, the intervals are the fewest intervals that describe the keys.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", step, want, got)
+
+		qLo := rnd.Intn(size)
+		qHi := qLo + rnd.Intn(size-qLo+1)
+
+		var gotOverlap []string
+
+		for interval, v := range m.Overlapping(qLo, qHi) {
+			gotOverlap = append(gotOverlap, fmt.Sprintf("[%d, %d)=%d", interval.Lo, interval.Hi, v))
+		}
+
+		wantOverlap := describeNaive(naive, qLo, qHi)
+
+		assert.EqualSf(t, gotOverlap, wantOverlap, "\n\n"+
+			"UT Name:  After step %d, the overlapping intervals of [%d, %d) are the keys in it.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", step, qLo, qHi, wantOverlap, gotOverlap)
+
+		var gotComplement, wantComplement []int
+
+		for interval := range m.Complement(qLo, qHi) {
+			for k := interval.Lo; k < interval.Hi; k++ {
+				gotComplement = append(gotComplement, k)
+			}
+		}
+
+		for k := qLo; k < qHi; k++ {
+			if _, ok := naive[k]; !ok {
+				wantComplement = append(wantComplement, k)
+			}
+		}
+
+		assert.EqualSf(t, gotComplement, wantComplement, "\n\n"+
+			"UT Name:  After step %d, the complement of [%d, %d) contains the keys that aren't mapped.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", step, qLo, qHi, wantComplement, gotComplement)
+
+		k := rnd.Intn(size)
+		gotV, gotOk := m.Get(k)
+		wantV, wantOk := naive[k]
+
+		assert.Equalf(t, fmt.Sprint(gotV, gotOk), fmt.Sprint(wantV, wantOk), "\n\n"+
+			"UT Name:  After step %d, the value of key %d matches.\n"+
+			"\033[32mExpected: %v, %t.\033[0m\n"+
+			"\033[31mActual:   %v, %t.\033[0m\n\n", step, k, wantV, wantOk, gotV, gotOk)
+	}
+}
+
+// Returns the intervals of m and their values, in the form "[lo, hi)=value".
+func describe(m *intervalmap.Map[int, int]) []string {
+	var out []string
+
+	for interval, v := range m.All() {
+		out = append(out, fmt.Sprintf("[%d, %d)=%d", interval.Lo, interval.Hi, v))
+	}
+
+	return out
+}
+
+// Returns the fewest intervals (within [lo, hi)) that describe the keys of naive, in the form "[lo, hi)=value".
+func describeNaive(naive map[int]int, lo, hi int) []string {
+	var out []string
+
+	for k := lo; k < hi; {
+		v, ok := naive[k]
+
+		if !ok {
+			k++
+
+			continue
+		}
+
+		end := k + 1
+
+		for ; end < hi; end++ {
+			if next, ok := naive[end]; !ok || next != v {
+				break
+			}
+		}
+
+		out = append(out, fmt.Sprintf("[%d, %d)=%d", k, end, v))
+		k = end
+	}
+
+	return out
+}
+
+var benchmarkOutput int // Output of the benchmark(s). Used to avoid compiler optimizations.
+
+// Benchmark(s): Insert intervals into an [intervalmap.Map].
+func BenchmarkMap_Insert_10(b *testing.B)    { benchmarkInsert(10, b) }
+func BenchmarkMap_Insert_100(b *testing.B)   { benchmarkInsert(100, b) }
+func BenchmarkMap_Insert_1000(b *testing.B)  { benchmarkInsert(1_000, b) }
+func BenchmarkMap_Insert_10000(b *testing.B) { benchmarkInsert(10_000, b) }
+
+// Benchmark: Measure the performance of inserting random intervals into an [intervalmap.Map].
+// Parameters:
+// - count: The amount of intervals to insert.
+// - b:     The [testing.B] instance.
+func benchmarkInsert(count int, b *testing.B) {
+	rnd := rand.New(rand.NewSource(48))
+	bounds := make([][2]int, count)
+
+	for idx := range bounds {
+		lo := rnd.Intn(0x10ffff)
+		bounds[idx] = [2]int{lo, lo + rnd.Intn(256)}
+	}
+
+	for b.Loop() {
+		m := intervalmap.New[int, int]()
+
+		for idx, bound := range bounds {
+			m.Insert(bound[0], bound[1], idx%4)
+		}
+
+		benchmarkOutput = m.Len()
+	}
+}
@@ -26,9 +26,17 @@
 // Package mvmap provided a tiny, generic, append-friendly multi-value map.
 package mvmap
 
+import (
+	"iter"
+	"slices"
+)
+
 // MvMap maps a key K to zero or more values of type V.
+// The keys are in an unspecified order, unless the map is insertion-ordered (see [NewOrdered]).
 type MvMap[K comparable, V any] struct {
-	data map[K][]V
+	data    map[K][]V
+	keys    []K  // The keys in the order in which they're added (only for an insertion-ordered map).
+	ordered bool // Indicates whether the keys are kept in the order in which they're added.
 }
 
 // New returns a new [MvMap] mapping K to []V.
@@ -45,13 +53,26 @@ func WithCapacity[K comparable, V any](cap int) *MvMap[K, V] {
 	}
 }
 
+// NewOrdered returns a new, insertion-ordered [MvMap] mapping K to []V.
+// The keys of an insertion-ordered map are returned in the order in which they're added (see [MvMap.Keys] and
+// [MvMap.All]), which makes iterating over the map deterministic. Deleting a key from an insertion-ordered map takes
+// time that's linear in the amount of keys.
+func NewOrdered[K comparable, V any]() *MvMap[K, V] {
+	return &MvMap[K, V]{
+		data:    make(map[K][]V),
+		ordered: true,
+	}
+}
+
 // SetKeyCap allocates a slice with a capacity of size for k.
 func (m *MvMap[K, V]) SetKeyCap(k K, size int) {
+	m.addKey(k)
 	m.data[k] = make([]V, 0, size)
 }
 
 // Put stores v as a value of k in the map.
 func (m *MvMap[K, V]) Put(k K, v V) {
+	m.addKey(k)
 	m.data[k] = append(m.data[k], v)
 }
 
@@ -60,6 +81,41 @@ func (m *MvMap[K, V]) Get(k K) []V {
 	return m.data[k]
 }
 
+// Delete removes k (and all of its values) from the map.
+func (m *MvMap[K, V]) Delete(k K) {
+	if _, ok := m.data[k]; !ok {
+		return
+	}
+
+	delete(m.data, k)
+
+	if m.ordered {
+		idx := slices.Index(m.keys, k)
+		m.keys = slices.Delete(m.keys, idx, idx+1)
+	}
+}
+
+// DeleteValue removes all the values of k in m that are equal to v. When no values of k remain, k is removed from m.
+// It reports whether any value was removed.
+func DeleteValue[K, V comparable](m *MvMap[K, V], k K, v V) bool {
+	values := m.data[k]
+	kept := slices.DeleteFunc(values, func(value V) bool {
+		return value == v
+	})
+
+	if len(kept) == len(values) {
+		return false
+	}
+
+	if len(kept) == 0 {
+		m.Delete(k)
+	} else {
+		m.data[k] = kept
+	}
+
+	return true
+}
+
 // Len returns the total amount of keys in the map.
 func (m *MvMap[K, V]) Len() int {
 	return len(m.data)
@@ -67,6 +123,10 @@ func (m *MvMap[K, V]) Len() int {
 
 // Keys returns all distinct keys in the map.
 func (m *MvMap[K, V]) Keys() []K {
+	if m.ordered {
+		return slices.Clone(m.keys)
+	}
+
 	out := make([]K, 0, m.Len())
 
 	for v := range m.data {
@@ -75,3 +135,49 @@ func (m *MvMap[K, V]) Keys() []K {
 
 	return out
 }
+
+// All returns an iterator over the keys of the map and their values.
+// The map must NOT be modified during the iteration.
+func (m *MvMap[K, V]) All() iter.Seq2[K, []V] {
+	return func(yield func(K, []V) bool) {
+		if !m.ordered {
+			for k, values := range m.data {
+				if !yield(k, values) {
+					return
+				}
+			}
+
+			return
+		}
+
+		for _, k := range m.keys {
+			if !yield(k, m.data[k]) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a new [MvMap] that contains the keys and values of m (in the same order, if m is insertion-ordered).
+// The values of each key are copied into a slice with the same capacity (see [MvMap.SetKeyCap]), so appending to the
+// values of the clone doesn't affect m.
+func (m *MvMap[K, V]) Clone() *MvMap[K, V] {
+	clone := &MvMap[K, V]{
+		data:    make(map[K][]V, len(m.data)),
+		keys:    slices.Clone(m.keys),
+		ordered: m.ordered,
+	}
+
+	for k, values := range m.data {
+		clone.data[k] = append(make([]V, 0, cap(values)), values...)
+	}
+
+	return clone
+}
+
+// Records k as a key of the map, if it isn't one yet.
+func (m *MvMap[K, V]) addKey(k K) {
+	if _, ok := m.data[k]; !ok && m.ordered {
+		m.keys = append(m.keys, k)
+	}
+}
@@ -252,6 +252,235 @@ func TestMvMap_Keys(t *testing.T) {
 	})
 }
 
+// UT: Create a new, insertion-ordered [mvmap.MvMap].
+func TestNewOrdered(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When adding keys, they're returned in the order in which they're added.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		m := mvmap.NewOrdered[int, int]()
+
+		// Act.
+		for _, k := range newSlice(5, 3, 9, 3, 1, 5, 7) {
+			m.Put(k, k*10)
+		}
+
+		got, want := m.Keys(), newSlice(5, 3, 9, 1, 7)
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  When adding keys, they're returned in the order in which they're added.\n"+
+			"\033[32mExpected: %d.\033[0m\n"+
+			"\033[31mActual:   %d.\033[0m\n\n", want, got)
+	})
+
+	t.Run("When specifying the size of a key, the key keeps its position and capacity.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		m := mvmap.NewOrdered[int, int]()
+		m.SetKeyCap(2, 10)
+		m.Put(1, 10)
+
+		// Act.
+		m.Put(2, 20)
+
+		got, want := append(m.Keys(), cap(m.Get(2))), newSlice(2, 1, 10)
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  When specifying the size of a key, the key keeps its position and capacity.\n"+
+			"\033[32mExpected: %d.\033[0m\n"+
+			"\033[31mActual:   %d.\033[0m\n\n", want, got)
+	})
+}
+
+// UT: Delete a key from a [mvmap.MvMap].
+func TestMvMap_Delete(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		m *mvmap.MvMap[int, int]
+	}{
+		"When deleting a key, the key and its values are removed.":                            {m: mvmap.New[int, int]()},
+		"When deleting a key from an insertion-ordered map, the other keys keep their order.": {m: mvmap.NewOrdered[int, int]()},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			tc.m.Put(1, 10)
+			tc.m.Put(2, 20)
+			tc.m.Put(2, 21)
+			tc.m.Put(3, 30)
+
+			// Act.
+			tc.m.Delete(2)
+			tc.m.Delete(4)
+
+			got, want := append(tc.m.Keys(), len(tc.m.Get(2))), newSlice(1, 3, 0)
+
+			// Assert.
+			sort.Ints(got[:2])
+
+			assert.EqualSf(t, got, want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d.\033[0m\n"+
+				"\033[31mActual:   %d.\033[0m\n\n", tcName, want, got)
+		})
+	}
+}
+
+// UT: Delete a value from a [mvmap.MvMap].
+func TestDeleteValue(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		k, v       int
+		wantOk     bool
+		wantKeys   []int
+		wantValues []int
+	}{
+		"When deleting a value, all of its occurrences are removed.": {
+			k: 1, v: 10, wantOk: true, wantKeys: newSlice(1, 2), wantValues: newSlice(11),
+		},
+		"When deleting the last value of a key, the key is removed.": {
+			k: 2, v: 20, wantOk: true, wantKeys: newSlice(1), wantValues: newSlice(10, 11, 10),
+		},
+		"When deleting a value that's NOT present, false is returned.": {
+			k: 1, v: 20, wantOk: false, wantKeys: newSlice(1, 2), wantValues: newSlice(10, 11, 10),
+		},
+		"When deleting a value of a key that's NOT present, false is returned.": {
+			k: 3, v: 10, wantOk: false, wantKeys: newSlice(1, 2), wantValues: newSlice(10, 11, 10),
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			m := mvmap.NewOrdered[int, int]()
+			m.Put(1, 10)
+			m.Put(1, 11)
+			m.Put(1, 10)
+			m.Put(2, 20)
+
+			// Act.
+			gotOk := mvmap.DeleteValue(m, tc.k, tc.v)
+
+			// Assert.
+			assert.Equalf(t, gotOk, tc.wantOk, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %t.\033[0m\n"+
+				"\033[31mActual:   %t.\033[0m\n\n", tcName, tc.wantOk, gotOk)
+
+			assert.EqualSf(t, m.Keys(), tc.wantKeys, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d.\033[0m\n"+
+				"\033[31mActual:   %d.\033[0m\n\n", tcName, tc.wantKeys, m.Keys())
+
+			assert.EqualSf(t, m.Get(1), tc.wantValues, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d.\033[0m\n"+
+				"\033[31mActual:   %d.\033[0m\n\n", tcName, tc.wantValues, m.Get(1))
+		})
+	}
+}
+
+// UT: Iterate over the keys and values of a [mvmap.MvMap].
+func TestMvMap_All(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When iterating, each key is yielded with its values.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		m := mvmap.New[int, int]()
+		m.Put(2, 20)
+		m.Put(1, 10)
+		m.Put(2, 21)
+
+		// Act.
+		got := make(map[int][]int)
+
+		for k, values := range m.All() {
+			got[k] = values
+		}
+
+		// Assert.
+		assert.EqualSf(t, got[1], newSlice(10), "\n\n"+
+			"UT Name:  When iterating, each key is yielded with its values.\n"+
+			"\033[32mExpected: %d.\033[0m\n"+
+			"\033[31mActual:   %d.\033[0m\n\n", newSlice(10), got[1])
+
+		assert.EqualSf(t, got[2], newSlice(20, 21), "\n\n"+
+			"UT Name:  When iterating, each key is yielded with its values.\n"+
+			"\033[32mExpected: %d.\033[0m\n"+
+			"\033[31mActual:   %d.\033[0m\n\n", newSlice(20, 21), got[2])
+	})
+
+	t.Run("When iterating over an insertion-ordered map, the keys are yielded in order.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		m := mvmap.NewOrdered[int, int]()
+
+		for _, k := range newSlice(4, 2, 8, 6) {
+			m.Put(k, k)
+		}
+
+		// Act.
+		var got []int
+
+		for k, values := range m.All() {
+			if k == 6 {
+				break
+			}
+
+			got = append(got, values...)
+		}
+
+		// Assert.
+		want := newSlice(4, 2, 8)
+
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  When iterating over an insertion-ordered map, the keys are yielded in order.\n"+
+			"\033[32mExpected: %d.\033[0m\n"+
+			"\033[31mActual:   %d.\033[0m\n\n", want, got)
+	})
+}
+
+// UT: Clone a [mvmap.MvMap].
+func TestMvMap_Clone(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	m := mvmap.NewOrdered[int, int]()
+	m.SetKeyCap(3, 4)
+	m.Put(3, 30)
+	m.Put(1, 10)
+
+	// Act.
+	clone := m.Clone()
+	clone.Put(3, 31)
+	clone.Put(2, 20)
+
+	got := append(append(m.Keys(), m.Get(3)...), append(clone.Keys(), clone.Get(3)...)...)
+
+	// Assert.
+	want := newSlice(3, 1, 30, 3, 1, 2, 30, 31)
+
+	assert.EqualSf(t, got, want, "\n\n"+
+		"UT Name:  When modifying a clone, the original map is unchanged.\n"+
+		"\033[32mExpected: %d.\033[0m\n"+
+		"\033[31mActual:   %d.\033[0m\n\n", want, got)
+}
+
 // Utility: Return a slice of integers, containing args.
 func newSlice(args ...int) []int {
 	container := make([]int, len(args))
@@ -0,0 +1,190 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package rope implements a rope: a text buffer that supports cheap edits, even when the text is large.
+package rope
+
+import (
+	"io"
+	"unicode/utf8"
+
+	"github.com/kdeconinck/align/internal/pkg/collections/deque"
+)
+
+// The amount of runes that a [RuneReader] remembers the width of, to unread them.
+const unreadHistory = 4096
+
+// ByteReader reads the bytes of a [Rope]. It implements the SymbolReader and MultiUnreader interfaces of the scanner
+// package, so a scanner for bytes can read directly from the rope.
+// The rope must NOT be modified while it's read.
+type ByteReader struct {
+	cursor
+}
+
+// NewByteReader returns a [ByteReader] that reads the bytes of r, starting at its first byte.
+func NewByteReader(r *Rope) *ByteReader {
+	return &ByteReader{cursor: cursor{rope: r}}
+}
+
+// ReadSymbol reads the next byte.
+func (bRdr *ByteReader) ReadSymbol() (byte, error) {
+	if bRdr.offset == bRdr.rope.Len() {
+		return 0, io.EOF
+	}
+
+	b := bRdr.chunk()[0]
+	bRdr.offset++
+
+	return b, nil
+}
+
+// UnreadSymbol unreads the last byte read.
+func (bRdr *ByteReader) UnreadSymbol() error {
+	return bRdr.UnreadSymbols(1)
+}
+
+// UnreadSymbols unreads the last n bytes read.
+func (bRdr *ByteReader) UnreadSymbols(n int) error {
+	if n > bRdr.offset {
+		return io.ErrUnexpectedEOF
+	}
+
+	bRdr.offset -= n
+
+	return nil
+}
+
+// RuneReader reads the runes of a [Rope], decoding its text as UTF-8 (an invalid byte is read as [utf8.RuneError]).
+// It implements the SymbolReader and MultiUnreader interfaces of the scanner package, so a scanner for runes can read
+// directly from the rope.
+// The rope must NOT be modified while it's read.
+//
+// NOTE: The reader remembers the width of the last runes it read. Runes before those are unread by decoding the text
+// backwards, which only matches the runes that were read if the text is valid UTF-8.
+type RuneReader struct {
+	cursor
+	widths *deque.Deque[int] // The width (in bytes) of the last runes read.
+}
+
+// NewRuneReader returns a [RuneReader] that reads the runes of r, starting at its first rune.
+func NewRuneReader(r *Rope) *RuneReader {
+	return &RuneReader{cursor: cursor{rope: r}, widths: deque.Bounded[int](unreadHistory)}
+}
+
+// ReadSymbol reads the next rune.
+func (rRdr *RuneReader) ReadSymbol() (rune, error) {
+	size := rRdr.rope.Len()
+
+	if rRdr.offset == size {
+		return 0, io.EOF
+	}
+
+	text := rRdr.chunk()
+
+	if !utf8.FullRuneInString(text) {
+		// NOTE: The rune continues in the next chunk.
+		text = rRdr.rope.Slice(rRdr.offset, min(rRdr.offset+utf8.UTFMax, size))
+	}
+
+	r, width := utf8.DecodeRuneInString(text)
+	rRdr.widths.PushBack(width)
+	rRdr.offset += width
+
+	return r, nil
+}
+
+// UnreadSymbol unreads the last rune read.
+func (rRdr *RuneReader) UnreadSymbol() error {
+	if rRdr.offset == 0 {
+		return io.ErrUnexpectedEOF
+	}
+
+	width, ok := rRdr.widths.PopBack()
+
+	if !ok {
+		_, width = utf8.DecodeLastRuneInString(rRdr.rope.Slice(max(rRdr.offset-utf8.UTFMax, 0), rRdr.offset))
+	}
+
+	rRdr.offset -= width
+
+	return nil
+}
+
+// UnreadSymbols unreads the last n runes read.
+func (rRdr *RuneReader) UnreadSymbols(n int) error {
+	for range n {
+		if err := rRdr.UnreadSymbol(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Tracks the offset of a reader in a [Rope], and caches the text of the node at that offset, so that reading the text
+// in order only searches the tree once per node.
+type cursor struct {
+	rope   *Rope
+	offset int
+	text   string // The text of the node that contains the byte at start.
+	start  int    // The offset of the first byte of text.
+}
+
+// Offset returns the offset (in bytes) in the rope of the next symbol to read.
+func (c *cursor) Offset() int {
+	return c.offset
+}
+
+// Returns the text of the node that contains the byte at the offset of c, starting at that byte.
+// The offset of c must be less than the size of the rope.
+func (c *cursor) chunk() string {
+	if c.offset < c.start || c.offset >= c.start+len(c.text) {
+		c.text, c.start = c.rope.root.chunkAt(c.offset)
+	}
+
+	return c.text[c.offset-c.start:]
+}
+
+// Returns the text of the node that contains the byte at offset in the subtree of n, and the offset of its first byte.
+func (n *node) chunkAt(offset int) (string, int) {
+	start := 0
+
+	for {
+		leftSize := sizeOf(n.left)
+
+		switch {
+		case offset < leftSize:
+			n = n.left
+
+		case offset < leftSize+len(n.text):
+			return n.text, start + leftSize
+
+		default:
+			offset -= leftSize + len(n.text)
+			start += leftSize + len(n.text)
+			n = n.right
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "rope" package.
+package rope_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/collections/rope"
+	"github.com/kdeconinck/align/internal/pkg/scanner"
+)
+
+// The readers of a [rope.Rope] can be used by a [scanner.Scanner].
+var (
+	_ scanner.SymbolReader[byte] = (*rope.ByteReader)(nil)
+	_ scanner.SymbolReader[rune] = (*rope.RuneReader)(nil)
+	_ scanner.MultiUnreader      = (*rope.ByteReader)(nil)
+	_ scanner.MultiUnreader      = (*rope.RuneReader)(nil)
+)
+
+// UT: Read the bytes of a [rope.Rope].
+func TestByteReader(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When reading, all the bytes are returned, followed by EOF.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		text := strings.Repeat("ab\ncd€", 300)
+		rdr := rope.NewByteReader(newEditedRope(text))
+
+		// Act.
+		got, err := readAll(rdr)
+
+		// Assert.
+		assert.Errorf(t, err, io.EOF, "\n\n"+
+			"UT Name:  When reading, all the bytes are returned, followed by EOF.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", io.EOF, err)
+
+		assert.EqualSf(t, got, []byte(text), "\n\n"+
+			"UT Name:  When reading, all the bytes are returned, followed by EOF.\n"+
+			"\033[32mExpected: %q.\033[0m\n"+
+			"\033[31mActual:   %q.\033[0m\n\n", text, got)
+	})
+
+	t.Run("When unreading, the bytes are read again.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		rdr := rope.NewByteReader(newEditedRope(strings.Repeat("abc", 300)))
+		_, _ = readAll(rdr)
+
+		// Act.
+		err := rdr.UnreadSymbols(600)
+		_ = rdr.UnreadSymbol()
+		b, _ := rdr.ReadSymbol()
+
+		got := fmt.Sprintf("%v %c %d", err, b, rdr.Offset())
+
+		// Assert.
+		want := "<nil> c 300"
+
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When unreading, the bytes are read again.\n"+
+			"\033[32mExpected: %s.\033[0m\n"+
+			"\033[31mActual:   %s.\033[0m\n\n", want, got)
+	})
+
+	t.Run("When unreading more bytes than are read, an error is returned.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		rdr := rope.NewByteReader(rope.New("ab"))
+		_, _ = rdr.ReadSymbol()
+
+		// Act.
+		err := rdr.UnreadSymbols(2)
+
+		// Assert.
+		assert.Errorf(t, err, io.ErrUnexpectedEOF, "\n\n"+
+			"UT Name:  When unreading more bytes than are read, an error is returned.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", io.ErrUnexpectedEOF, err)
+	})
+}
+
+// UT: Read the runes of a [rope.Rope].
+func TestRuneReader(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		text string
+	}{
+		"When reading runes that span multiple chunks, the runes are decoded.": {
+			text: strings.Repeat("a", 511) + "€" + strings.Repeat("é😀", 500),
+		},
+		"When reading invalid UTF-8, each invalid byte is read as an error rune.": {
+			text: "a\xe2\x82b\xffc",
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			rdr := rope.NewRuneReader(rope.New(tc.text))
+
+			// Act.
+			got, err := readAll(rdr)
+
+			// Assert.
+			assert.Errorf(t, err, io.EOF, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, io.EOF, err)
+
+			assert.EqualSf(t, got, []rune(tc.text), "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %q.\033[0m\n"+
+				"\033[31mActual:   %q.\033[0m\n\n", tcName, []rune(tc.text), got)
+		})
+	}
+
+	for tcName, tc := range map[string]struct {
+		text string
+	}{
+		"When unreading runes, the runes are read again.": {
+			text: "a\xe2\x82€b" + strings.Repeat("é😀", 500),
+		},
+		"When unreading more runes than the reader remembers, the runes are decoded backwards.": {
+			text: strings.Repeat("aé😀€", 3_000),
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			rdr := rope.NewRuneReader(rope.New(tc.text))
+			_, _ = readAll(rdr)
+
+			// Act.
+			err := rdr.UnreadSymbols(len([]rune(tc.text)))
+			got, _ := readAll(rdr)
+
+			// Assert.
+			assert.Nilf(t, err, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: <nil>.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, err)
+
+			assert.EqualSf(t, got, []rune(tc.text), "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %q.\033[0m\n"+
+				"\033[31mActual:   %q.\033[0m\n\n", tcName, []rune(tc.text), got)
+		})
+	}
+
+	t.Run("When unreading at the start of the rope, an error is returned.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		rdr := rope.NewRuneReader(rope.New("é"))
+
+		// Act.
+		err := rdr.UnreadSymbol()
+
+		// Assert.
+		assert.Errorf(t, err, io.ErrUnexpectedEOF, "\n\n"+
+			"UT Name:  When unreading at the start of the rope, an error is returned.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", io.ErrUnexpectedEOF, err)
+	})
+}
+
+// UT: Scan the tokens of a [rope.Rope].
+func TestRuneReader_Scan(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	text := strings.Repeat("ké€ word\r\n  😀x\n", 200)
+	lexer := scanner.NewScannerBuilder[rune, string]().
+		Add(scanner.RepeatAtLeast(1, scanner.AnyOf(
+			scanner.Literal[rune, string]('k'), scanner.Literal[rune, string]('é'),
+			scanner.Literal[rune, string]('€'), scanner.Literal[rune, string]('w'),
+			scanner.Literal[rune, string]('o'), scanner.Literal[rune, string]('r'),
+			scanner.Literal[rune, string]('d'), scanner.Literal[rune, string]('x'),
+		)), "WORD").
+		Add(scanner.RepeatAtLeast(1, scanner.AnyOf(
+			scanner.Literal[rune, string](' '), scanner.Literal[rune, string]('\r'), scanner.Literal[rune, string]('\n'),
+		)), "WS").
+		BuildLexer("ILLEGAL", "EOF")
+
+	// Act.
+	got, err := scanner.Collect(lexer.NewScanner().All(rope.NewRuneReader(newEditedRope(text))))
+	want, _ := scanner.Collect(lexer.NewScanner().All(scanner.NewSliceReader([]rune(text))))
+
+	// Assert.
+	assert.Nilf(t, err, "\n\n"+
+		"UT Name:  When scanning a rope, NO error is returned.\n"+
+		"\033[32mExpected: <nil>.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", err)
+
+	assert.EqualSf(t, formatTokens(got), formatTokens(want), "\n\n"+
+		"UT Name:  When scanning a rope, the tokens match the ones of its text.\n"+
+		"\033[32mExpected: %v.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", formatTokens(want), formatTokens(got))
+}
+
+// Returns a [rope.Rope] that contains text, which is built by a sequence of edits (so that the text is spread over
+// many nodes).
+func newEditedRope(text string) *rope.Rope {
+	r := rope.New(text)
+
+	for offset := 0; offset < len(text); offset += 7 {
+		r.Insert(offset, "#")
+		r.Delete(offset, offset+1)
+	}
+
+	return r
+}
+
+// Returns the symbols read from rdr, until it returns an error, and that error.
+func readAll[S comparable](rdr scanner.SymbolReader[S]) ([]S, error) {
+	var out []S
+
+	for {
+		sym, err := rdr.ReadSymbol()
+
+		if err != nil {
+			return out, err
+		}
+
+		out = append(out, sym)
+	}
+}
+
+// Returns the value, lexeme and location of each token in tokens.
+func formatTokens(tokens []scanner.Token[rune, string]) []string {
+	out := make([]string, 0, len(tokens))
+
+	for _, token := range tokens {
+		out = append(out, fmt.Sprintf("%s(%q)@%v-%v/%d", token.Value, string(token.Lexeme), token.Span.Start,
+			token.Span.End, token.Span.End.Offset))
+	}
+
+	return out
+}
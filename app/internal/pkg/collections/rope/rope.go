@@ -0,0 +1,450 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package rope implements a rope: a text buffer that supports cheap edits, even when the text is large.
+package rope
+
+import (
+	"math/rand/v2"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kdeconinck/align/internal/pkg/pos"
+)
+
+// The maximum size (in bytes) of the text of a node that's created for an insert.
+const maxChunkSize = 512
+
+// Rope is a text buffer, backed by a balanced tree (a treap) of chunks of text.
+// Inserting and deleting text takes O(log n) time (plus the size of the inserted text), regardless of the size of the
+// text. The tree keeps track of the line breaks, so locating a line takes O(log n) time as well, and locating a
+// [pos.Position] takes O(log n) time plus the length of its line.
+// Lines end with a "\n" or a "\r\n" (see [pos.DefaultTerminators]).
+// A Rope is NOT safe for concurrent use.
+type Rope struct {
+	root *node
+}
+
+// A node of the tree. The text of a subtree is the text of its left subtree, followed by the text of the node itself
+// and the text of its right subtree.
+type node struct {
+	text        string
+	left, right *node
+	priority    uint64 // A random priority: the priority of a node is at least the priority of its children.
+	size        int    // The amount of bytes in the subtree.
+	breaks      int    // The amount of line breaks in the subtree.
+	textBreaks  int    // The amount of line breaks in the text of the node itself.
+}
+
+// New returns a [Rope] that contains text.
+func New(text string) *Rope {
+	return &Rope{root: build(text)}
+}
+
+// Len returns the amount of bytes in the rope.
+func (r *Rope) Len() int {
+	return sizeOf(r.root)
+}
+
+// LineCount returns the amount of lines in the rope.
+func (r *Rope) LineCount() int {
+	return breaksOf(r.root) + 1
+}
+
+// String returns the text of the rope.
+func (r *Rope) String() string {
+	var b strings.Builder
+	b.Grow(r.Len())
+
+	r.root.walk(func(text string) {
+		b.WriteString(text)
+	})
+
+	return b.String()
+}
+
+// Slice returns the text of the rope in the range [from, to).
+// Panics if the range is outside of the rope.
+func (r *Rope) Slice(from, to int) string {
+	if from < 0 || to > r.Len() || from > to {
+		panic("Slice: range is outside of the rope")
+	}
+
+	var b strings.Builder
+	b.Grow(to - from)
+
+	r.root.slice(from, to, &b)
+
+	return b.String()
+}
+
+// Insert inserts text at offset.
+// Panics if offset is outside of the rope.
+func (r *Rope) Insert(offset int, text string) {
+	if offset < 0 || offset > r.Len() {
+		panic("Insert: offset is outside of the rope")
+	}
+
+	if len(text) == 0 {
+		return
+	}
+
+	left, right := split(r.root, offset)
+	r.root = join(join(left, build(text)), right)
+}
+
+// Delete deletes the text in the range [from, to).
+// Panics if the range is outside of the rope.
+func (r *Rope) Delete(from, to int) {
+	if from < 0 || to > r.Len() || from > to {
+		panic("Delete: range is outside of the rope")
+	}
+
+	left, rest := split(r.root, from)
+	_, right := split(rest, to-from)
+	r.root = join(left, right)
+}
+
+// Replace replaces the text in the range [from, to) by text.
+// Panics if the range is outside of the rope.
+func (r *Rope) Replace(from, to int, text string) {
+	if from < 0 || to > r.Len() || from > to {
+		panic("Replace: range is outside of the rope")
+	}
+
+	r.Delete(from, to)
+	r.Insert(from, text)
+}
+
+// Line returns the line that contains the byte at offset.
+// An offset equal to the size of the rope is part of the last line.
+// Panics if offset is outside of the rope.
+func (r *Rope) Line(offset int) int {
+	if offset < 0 || offset > r.Len() {
+		panic("Line: offset is outside of the rope")
+	}
+
+	return r.root.breaksBefore(offset) + 1
+}
+
+// LineStart returns the offset of the first byte of line.
+// Panics if line is outside of the rope.
+func (r *Rope) LineStart(line int) int {
+	if line < 1 || line > r.LineCount() {
+		panic("LineStart: line is outside of the rope")
+	}
+
+	if line == 1 {
+		return 0
+	}
+
+	return r.root.offsetOfBreak(line-1) + 1
+}
+
+// Position returns the [pos.Position] of the byte at offset, as [pos.Position.Advance] computes it.
+// Panics if offset is outside of the rope.
+func (r *Rope) Position(offset int) pos.Position {
+	line := r.Line(offset)
+	p := pos.Position{Line: line, Column: 1, Offset: r.LineStart(line)}
+
+	for prefix := r.Slice(p.Offset, offset); len(prefix) > 0; {
+		ch, width := utf8.DecodeRuneInString(prefix)
+		p.Advance(ch, width)
+		prefix = prefix[width:]
+	}
+
+	return p
+}
+
+// OffsetOf returns the offset of the rune at column (counted in runes, as [pos.Position.Advance] counts them) of line.
+// A column after the end of the line resolves to the end of the line (before its "\n" or "\r\n").
+// Panics if line is outside of the rope or if column is less than 1.
+func (r *Rope) OffsetOf(line, column int) int {
+	if line < 1 || line > r.LineCount() {
+		panic("OffsetOf: line is outside of the rope")
+	}
+
+	if column < 1 {
+		panic("OffsetOf: column must be at least 1")
+	}
+
+	start, end := r.LineStart(line), r.Len()
+
+	if line < r.LineCount() {
+		end = r.LineStart(line+1) - len("\n")
+
+		if end > start && r.Slice(end-1, end) == "\r" {
+			end--
+		}
+	}
+
+	p := pos.Position{Line: line, Column: 1, Offset: start}
+
+	for text := r.Slice(start, end); len(text) > 0; {
+		ch, width := utf8.DecodeRuneInString(text)
+		next := p
+		next.Advance(ch, width)
+
+		if next.Column > column {
+			break
+		}
+
+		p, text = next, text[width:]
+	}
+
+	return p.Offset
+}
+
+// Returns a tree that contains text, split into chunks of at most [maxChunkSize] bytes.
+// Each chunk is a copy, so the tree doesn't keep text alive once (part of) it is deleted.
+func build(text string) *node {
+	var root *node
+
+	for len(text) > 0 {
+		size := min(len(text), maxChunkSize)
+		root = merge(root, newNode(strings.Clone(text[:size])))
+		text = text[size:]
+	}
+
+	return root
+}
+
+// Returns a new node (without children) that contains text.
+func newNode(text string) *node {
+	n := &node{text: text, priority: rand.Uint64(), textBreaks: strings.Count(text, "\n")}
+	n.update()
+
+	return n
+}
+
+// Updates the size and the amount of line breaks of the subtree of n, given the ones of its children.
+func (n *node) update() {
+	n.size = sizeOf(n.left) + len(n.text) + sizeOf(n.right)
+	n.breaks = breaksOf(n.left) + n.textBreaks + breaksOf(n.right)
+}
+
+// Returns the amount of bytes in the subtree of n (0 if n is nil).
+func sizeOf(n *node) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+}
+
+// Returns the amount of line breaks in the subtree of n (0 if n is nil).
+func breaksOf(n *node) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.breaks
+}
+
+// Returns a tree that contains the text of a, followed by the text of b.
+func merge(a, b *node) *node {
+	switch {
+	case a == nil:
+		return b
+
+	case b == nil:
+		return a
+
+	case a.priority > b.priority:
+		a.right = merge(a.right, b)
+		a.update()
+
+		return a
+
+	default:
+		b.left = merge(a, b.left)
+		b.update()
+
+		return b
+	}
+}
+
+// Returns a tree that contains the text of a, followed by the text of b.
+// Unlike [merge], the last node of a and the first node of b are combined into a single node when their text fits in
+// [maxChunkSize] bytes, which prevents edits from leaving behind a growing amount of tiny nodes.
+func join(a, b *node) *node {
+	if a == nil || b == nil {
+		return merge(a, b)
+	}
+
+	last, first := a.last(), b.first()
+
+	if len(last.text)+len(first.text) > maxChunkSize {
+		return merge(a, b)
+	}
+
+	a, _ = split(a, a.size-len(last.text))
+	_, b = split(b, len(first.text))
+
+	return merge(merge(a, newNode(last.text+first.text)), b)
+}
+
+// Returns the first node of the subtree of n.
+func (n *node) first() *node {
+	for n.left != nil {
+		n = n.left
+	}
+
+	return n
+}
+
+// Returns the last node of the subtree of n.
+func (n *node) last() *node {
+	for n.right != nil {
+		n = n.right
+	}
+
+	return n
+}
+
+// Splits the tree n into a tree that contains the first offset bytes, and a tree that contains the rest.
+// A node whose text contains offset is split into two nodes, each with a copy of its part of the text.
+func split(n *node, offset int) (*node, *node) {
+	if n == nil {
+		return nil, nil
+	}
+
+	leftSize := sizeOf(n.left)
+
+	switch {
+	case offset <= leftSize:
+		left, right := split(n.left, offset)
+		n.left = right
+		n.update()
+
+		return left, n
+
+	case offset >= leftSize+len(n.text):
+		left, right := split(n.right, offset-leftSize-len(n.text))
+		n.right = left
+		n.update()
+
+		return n, right
+
+	default:
+		cut := offset - leftSize
+		tail, right := newNode(strings.Clone(n.text[cut:])), n.right
+		n.text, n.right = strings.Clone(n.text[:cut]), nil
+		n.textBreaks = strings.Count(n.text, "\n")
+		n.update()
+
+		return n, merge(tail, right)
+	}
+}
+
+// Calls fn with the text of each node of the subtree of n, in order.
+func (n *node) walk(fn func(text string)) {
+	if n == nil {
+		return
+	}
+
+	n.left.walk(fn)
+	fn(n.text)
+	n.right.walk(fn)
+}
+
+// Writes the text of the subtree of n in the range [from, to) to b.
+func (n *node) slice(from, to int, b *strings.Builder) {
+	if n == nil || from >= to {
+		return
+	}
+
+	leftSize := sizeOf(n.left)
+
+	if from < leftSize {
+		n.left.slice(from, min(to, leftSize), b)
+	}
+
+	if start, end := max(from-leftSize, 0), min(to-leftSize, len(n.text)); start < end {
+		b.WriteString(n.text[start:end])
+	}
+
+	if rightStart := leftSize + len(n.text); to > rightStart {
+		n.right.slice(max(from-rightStart, 0), to-rightStart, b)
+	}
+}
+
+// Returns the amount of line breaks in the first offset bytes of the subtree of n.
+func (n *node) breaksBefore(offset int) int {
+	count := 0
+
+	for n != nil {
+		leftSize := sizeOf(n.left)
+
+		switch {
+		case offset <= leftSize:
+			n = n.left
+
+		case offset <= leftSize+len(n.text):
+			return count + breaksOf(n.left) + strings.Count(n.text[:offset-leftSize], "\n")
+
+		default:
+			count += breaksOf(n.left) + n.textBreaks
+			offset -= leftSize + len(n.text)
+			n = n.right
+		}
+	}
+
+	return count
+}
+
+// Returns the offset of the line break with the given index (starting at 1) in the subtree of n.
+// The subtree must contain at least that many line breaks.
+func (n *node) offsetOfBreak(idx int) int {
+	offset := 0
+
+	for {
+		leftBreaks := breaksOf(n.left)
+
+		switch {
+		case idx <= leftBreaks:
+			n = n.left
+
+		case idx <= leftBreaks+n.textBreaks:
+			return offset + sizeOf(n.left) + indexOfBreak(n.text, idx-leftBreaks)
+
+		default:
+			idx -= leftBreaks + n.textBreaks
+			offset += sizeOf(n.left) + len(n.text)
+			n = n.right
+		}
+	}
+}
+
+// Returns the index of the line break with the given index (starting at 1) in text.
+func indexOfBreak(text string, idx int) int {
+	at := -1
+
+	for range idx {
+		at += strings.IndexByte(text[at+1:], '\n') + 1
+	}
+
+	return at
+}
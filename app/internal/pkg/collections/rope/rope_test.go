@@ -0,0 +1,345 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "rope" package.
+package rope_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/collections/rope"
+	"github.com/kdeconinck/align/internal/pkg/pos"
+)
+
+// UT: Create a new [rope.Rope].
+func TestNew(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		text      string
+		wantLines int
+	}{
+		"An empty rope contains a single (empty) line.":                   {text: "", wantLines: 1},
+		"A rope contains the lines of its text.":                          {text: "a\nb\r\nc", wantLines: 3},
+		"A rope that ends with a line break ends with an empty line.":     {text: "a\n", wantLines: 2},
+		"A rope with a text larger than a chunk contains the whole text.": {text: strings.Repeat("abc\n", 1_000), wantLines: 1_001},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			r := rope.New(tc.text)
+
+			// Assert.
+			assert.Equalf(t, r.String(), tc.text, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %q.\033[0m\n"+
+				"\033[31mActual:   %q.\033[0m\n\n", tcName, tc.text, r.String())
+
+			assert.Equalf(t, r.Len(), len(tc.text), "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d.\033[0m\n"+
+				"\033[31mActual:   %d.\033[0m\n\n", tcName, len(tc.text), r.Len())
+
+			assert.Equalf(t, r.LineCount(), tc.wantLines, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d.\033[0m\n"+
+				"\033[31mActual:   %d.\033[0m\n\n", tcName, tc.wantLines, r.LineCount())
+		})
+	}
+}
+
+// UT: Edit the text of a [rope.Rope].
+func TestRope_Edit(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		text string
+		edit func(r *rope.Rope)
+		want string
+	}{
+		"Inserting text at the start prepends it.": {
+			text: "world", edit: func(r *rope.Rope) { r.Insert(0, "hello, ") }, want: "hello, world",
+		},
+		"Inserting text at the end appends it.": {
+			text: "hello", edit: func(r *rope.Rope) { r.Insert(5, ", world") }, want: "hello, world",
+		},
+		"Inserting text into an empty rope sets the text.": {
+			text: "", edit: func(r *rope.Rope) { r.Insert(0, "a\nb") }, want: "a\nb",
+		},
+		"Deleting a range removes its text.": {
+			text: "hello, world", edit: func(r *rope.Rope) { r.Delete(5, 12) }, want: "hello",
+		},
+		"Deleting an empty range doesn't change the text.": {
+			text: "hello", edit: func(r *rope.Rope) { r.Delete(2, 2) }, want: "hello",
+		},
+		"Replacing a range replaces its text.": {
+			text: "hello, world", edit: func(r *rope.Rope) { r.Replace(7, 12, "rope") }, want: "hello, rope",
+		},
+		"Typing text byte by byte and deleting it again keeps the text of both sides.": {
+			text: "hello, world",
+			edit: func(r *rope.Rope) {
+				for idx := range 2_000 {
+					r.Insert(7+idx, string(rune('a'+idx%26)))
+				}
+
+				for range 1_995 {
+					r.Delete(r.Len()-6, r.Len()-5)
+				}
+			},
+			want: "hello, abcde" + "world",
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			r := rope.New(tc.text)
+
+			// Act.
+			tc.edit(r)
+
+			// Assert.
+			assert.Equalf(t, r.String(), tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %q.\033[0m\n"+
+				"\033[31mActual:   %q.\033[0m\n\n", tcName, tc.want, r.String())
+		})
+	}
+}
+
+// UT: Use a [rope.Rope] with invalid arguments.
+func TestRope_Panic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		fn func(r *rope.Rope)
+	}{
+		"Slicing a range that ends after the rope causes a panic.":   {fn: func(r *rope.Rope) { r.Slice(1, 4) }},
+		"Slicing a range that ends before it starts causes a panic.": {fn: func(r *rope.Rope) { r.Slice(2, 1) }},
+		"Inserting at a negative offset causes a panic.":             {fn: func(r *rope.Rope) { r.Insert(-1, "x") }},
+		"Inserting after the rope causes a panic.":                   {fn: func(r *rope.Rope) { r.Insert(4, "x") }},
+		"Deleting a range that ends after the rope causes a panic.":  {fn: func(r *rope.Rope) { r.Delete(0, 4) }},
+		"Replacing a range that starts before the rope causes a panic.": {
+			fn: func(r *rope.Rope) { r.Replace(-1, 1, "x") },
+		},
+		"Getting the line of an offset after the rope causes a panic.": {fn: func(r *rope.Rope) { r.Line(4) }},
+		"Getting the start of a line after the rope causes a panic.":   {fn: func(r *rope.Rope) { r.LineStart(3) }},
+		"Getting the start of line 0 causes a panic.":                  {fn: func(r *rope.Rope) { r.LineStart(0) }},
+		"Getting the position of a negative offset causes a panic.":    {fn: func(r *rope.Rope) { r.Position(-1) }},
+		"Getting the offset of a line after the rope causes a panic.":  {fn: func(r *rope.Rope) { r.OffsetOf(3, 1) }},
+		"Getting the offset of a column less than 1 causes a panic.":   {fn: func(r *rope.Rope) { r.OffsetOf(1, 0) }},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			r := rope.New("a\nb")
+
+			handler := func() {
+				tc.fn(r)
+			}
+
+			// Act / assert.
+			assert.Panicf(t, handler, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+				"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n", tcName)
+		})
+	}
+}
+
+// UT: Locate the lines and positions of a [rope.Rope].
+func TestRope_Lines(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When locating the lines, the offsets of their first bytes are returned.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		r := rope.New("ab\r\n\ncd")
+
+		// Act.
+		got := []int{r.LineStart(1), r.LineStart(2), r.LineStart(3), r.Line(0), r.Line(3), r.Line(4), r.Line(7)}
+
+		// Assert.
+		want := []int{0, 4, 5, 1, 1, 2, 3}
+
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  When locating the lines, the offsets of their first bytes are returned.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("When getting the position of an offset, the columns are counted in runes.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		r := rope.New("x\nké€y")
+
+		// Act.
+		got := r.Position(8)
+
+		// Assert.
+		want := pos.Position{Line: 2, Column: 4, Offset: 8}
+
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  When getting the position of an offset, the columns are counted in runes.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("When getting the offset of a column after the end of a line, the end of the line is returned.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		r := rope.New("ab\r\ncd")
+
+		// Act.
+		got := []int{r.OffsetOf(1, 2), r.OffsetOf(1, 9), r.OffsetOf(2, 9)}
+
+		// Assert.
+		want := []int{1, 2, 6}
+
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  When getting the offset of a column after the end of a line, the end of the line is returned.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+}
+
+// UT: Compare a [rope.Rope] with a string and its [pos.LineIndex], for a random sequence of edits.
+func TestRope_Differential(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	rnd := rand.New(rand.NewSource(50))
+	alphabet := []string{"a", "b", " ", "\n", "\r", "\r\n", "é", "€", "😀"}
+	r, text := rope.New(""), ""
+
+	for step := range 300 {
+		var b strings.Builder
+
+		for range rnd.Intn(600) {
+			b.WriteString(alphabet[rnd.Intn(len(alphabet))])
+		}
+
+		from := rnd.Intn(len(text) + 1)
+		to := from + rnd.Intn(min(len(text)-from, 200)+1)
+
+		// Act.
+		if rnd.Intn(3) == 0 && len(text) > 2_000 {
+			r.Delete(from, to)
+			text = text[:from] + text[to:]
+		} else {
+			r.Replace(from, to, b.String())
+			text = text[:from] + b.String() + text[to:]
+		}
+
+		// Assert.
+		assert.Equalf(t, r.String(), text, "\n\n"+
+			"UT Name:  After step %d, the rope contains the text.\n"+
+			"\033[32mExpected: %q.\033[0m\n"+
+			"\033[31mActual:   %q.\033[0m\n\n", step, text, r.String())
+
+		li := pos.NewLineIndex(text)
+
+		assert.Equalf(t, r.LineCount(), li.LineCount(), "\n\n"+
+			"UT Name:  After step %d, the rope contains the lines of the text.\n"+
+			"\033[32mExpected: %d.\033[0m\n"+
+			"\033[31mActual:   %d.\033[0m\n\n", step, li.LineCount(), r.LineCount())
+
+		offset := rnd.Intn(len(text) + 1)
+		got, want := r.Position(offset), li.Position(offset)
+
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  After step %d, the position of offset %d matches the line index.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", step, offset, want, got)
+
+		line, column := 1+rnd.Intn(li.LineCount()), 1+rnd.Intn(40)
+		gotOffset, wantOffset := r.OffsetOf(line, column), li.PositionOf(line, column, pos.Runes).Offset
+
+		assert.Equalf(t, gotOffset, wantOffset, "\n\n"+
+			"UT Name:  After step %d, the offset of %d:%d matches the line index.\n"+
+			"\033[32mExpected: %d.\033[0m\n"+
+			"\033[31mActual:   %d.\033[0m\n\n", step, line, column, wantOffset, gotOffset)
+
+		sFrom := rnd.Intn(len(text) + 1)
+		sTo := sFrom + rnd.Intn(len(text)-sFrom+1)
+
+		assert.Equalf(t, r.Slice(sFrom, sTo), text[sFrom:sTo], "\n\n"+
+			"UT Name:  After step %d, the slice [%d, %d) matches the text.\n"+
+			"\033[32mExpected: %q.\033[0m\n"+
+			"\033[31mActual:   %q.\033[0m\n\n", step, sFrom, sTo, text[sFrom:sTo], r.Slice(sFrom, sTo))
+	}
+}
+
+var benchmarkOutput int // Output of the benchmark(s). Used to avoid compiler optimizations.
+
+// Benchmark(s): Insert text in the middle of a [rope.Rope].
+func BenchmarkRope_Insert_1000(b *testing.B)    { benchmarkRopeInsert(1_000, b) }
+func BenchmarkRope_Insert_1000000(b *testing.B) { benchmarkRopeInsert(1_000_000, b) }
+
+// Benchmark(s): Insert text in the middle of a string.
+func BenchmarkString_Insert_1000(b *testing.B)    { benchmarkStringInsert(1_000, b) }
+func BenchmarkString_Insert_1000000(b *testing.B) { benchmarkStringInsert(1_000_000, b) }
+
+// Benchmark: Measure the performance of inserting text in the middle of a [rope.Rope].
+// Parameters:
+// - size: The amount of bytes in the rope.
+// - b:    The [testing.B] instance.
+func benchmarkRopeInsert(size int, b *testing.B) {
+	r := rope.New(strings.Repeat("abcdefg\n", size/8))
+
+	for b.Loop() {
+		r.Insert(r.Len()/2, "x")
+	}
+
+	benchmarkOutput = r.LineCount()
+}
+
+// Benchmark: Measure the performance of inserting text in the middle of a string.
+// Parameters:
+// - size: The amount of bytes in the string.
+// - b:    The [testing.B] instance.
+func benchmarkStringInsert(size int, b *testing.B) {
+	text := strings.Repeat("abcdefg\n", size/8)
+
+	for b.Loop() {
+		text = text[:len(text)/2] + "x" + text[len(text)/2:]
+	}
+
+	benchmarkOutput = len(text)
+}
@@ -26,6 +26,13 @@
 // Package set implements a tiny, generic set (unique elements) implementation built on top of Go's built-in map.
 package set
 
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+)
+
 // Set is a container holding unique T elements.
 type Set[T comparable] map[T]struct {
 	// NOTE: Intentionally left blank.
@@ -46,6 +53,11 @@ func (s Set[T]) Add(v T) {
 	s[v] = struct{}{}
 }
 
+// Remove removes v from the set (if it's present).
+func (s Set[T]) Remove(v T) {
+	delete(s, v)
+}
+
 // Has reports whether v is present in the set.
 func (s Set[T]) Has(v T) bool {
 	_, ok := s[v]
@@ -68,3 +80,106 @@ func (s Set[T]) Values() []T {
 func (s Set[T]) Len() int {
 	return len(s)
 }
+
+// All returns an iterator over the elements of the set, in an unspecified order.
+func (s Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s)
+}
+
+// Clone returns a new [Set] that contains the elements of s.
+func (s Set[T]) Clone() Set[T] {
+	out := WithCapacity[T](len(s))
+
+	for v := range s {
+		out.Add(v)
+	}
+
+	return out
+}
+
+// Union returns a new [Set] that contains the elements that are present in s, in other, or in both.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	out := WithCapacity[T](len(s) + len(other))
+
+	for v := range s {
+		out.Add(v)
+	}
+
+	for v := range other {
+		out.Add(v)
+	}
+
+	return out
+}
+
+// Intersect returns a new [Set] that contains the elements that are present in both s and other.
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	if len(other) < len(s) {
+		s, other = other, s
+	}
+
+	out := New[T]()
+
+	for v := range s {
+		if other.Has(v) {
+			out.Add(v)
+		}
+	}
+
+	return out
+}
+
+// Difference returns a new [Set] that contains the elements of s that are NOT present in other.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	out := New[T]()
+
+	for v := range s {
+		if !other.Has(v) {
+			out.Add(v)
+		}
+	}
+
+	return out
+}
+
+// SymmetricDifference returns a new [Set] that contains the elements that are present in either s or other, but NOT
+// in both.
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	out := s.Difference(other)
+
+	for v := range other {
+		if !s.Has(v) {
+			out.Add(v)
+		}
+	}
+
+	return out
+}
+
+// IsSubset reports whether all the elements of s are present in other.
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+
+	for v := range s {
+		if !other.Has(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal reports whether s and other contain the same elements.
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubset(other)
+}
+
+// SortedValues returns a slice containing all elements currently in s, in ascending order.
+func SortedValues[T cmp.Ordered](s Set[T]) []T {
+	out := s.Values()
+	slices.Sort(out)
+
+	return out
+}
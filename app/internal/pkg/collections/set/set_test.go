@@ -215,6 +215,229 @@ func TestSet_Values(t *testing.T) {
 	})
 }
 
+// UT: Remove an element from a [set.Set].
+func TestSet_Remove(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("When removing an element, the element is NOT present anymore.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := newSet(1, 2, 3)
+
+		// Act.
+		s.Remove(2)
+
+		// Assert.
+		got, want := set.SortedValues(s), newSlice(1, 3)
+
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  When removing an element, the element is NOT present anymore.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("When removing an element that's NOT present, the set is unchanged.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := newSet(1, 2, 3)
+
+		// Act.
+		s.Remove(4)
+
+		// Assert.
+		got, want := set.SortedValues(s), newSlice(1, 2, 3)
+
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  When removing an element that's NOT present, the set is unchanged.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+}
+
+// UT: Iterate over the elements of a [set.Set].
+func TestSet_All(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	s := newSet(3, 1, 2)
+
+	// Act.
+	var got []int
+
+	for v := range s.All() {
+		got = append(got, v)
+	}
+
+	// Assert.
+	want := newSlice(1, 2, 3)
+	sort.Ints(got)
+
+	assert.EqualSf(t, got, want, "\n\n"+
+		"UT Name:  When iterating over a set, all the elements are yielded.\n"+
+		"\033[32mExpected: %v.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", want, got)
+}
+
+// UT: Clone a [set.Set].
+func TestSet_Clone(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	s := newSet(1, 2)
+
+	// Act.
+	clone := s.Clone()
+	clone.Add(3)
+
+	// Assert.
+	got, want := set.SortedValues(s), newSlice(1, 2)
+
+	assert.EqualSf(t, got, want, "\n\n"+
+		"UT Name:  When modifying a clone, the original set is unchanged.\n"+
+		"\033[32mExpected: %v.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", want, got)
+}
+
+// UT: Combine two [set.Set]s.
+func TestSet_Algebra(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		s, other []int
+		combine  func(s, other set.Set[int]) set.Set[int]
+		want     []int
+	}{
+		"The union contains the elements of both sets.": {
+			s: newSlice(1, 2, 3), other: newSlice(3, 4),
+			combine: set.Set[int].Union,
+			want:    newSlice(1, 2, 3, 4),
+		},
+		"The union with an empty set contains the elements of the set.": {
+			s: newSlice(1, 2), other: newSlice(),
+			combine: set.Set[int].Union,
+			want:    newSlice(1, 2),
+		},
+		"The intersection contains the elements that are present in both sets.": {
+			s: newSlice(1, 2, 3), other: newSlice(2, 3, 4, 5),
+			combine: set.Set[int].Intersect,
+			want:    newSlice(2, 3),
+		},
+		"The intersection of disjoint sets is empty.": {
+			s: newSlice(1, 2), other: newSlice(3),
+			combine: set.Set[int].Intersect,
+			want:    newSlice(),
+		},
+		"The difference contains the elements that are NOT present in the other set.": {
+			s: newSlice(1, 2, 3), other: newSlice(2, 4),
+			combine: set.Set[int].Difference,
+			want:    newSlice(1, 3),
+		},
+		"The symmetric difference contains the elements that are present in only one of the sets.": {
+			s: newSlice(1, 2, 3), other: newSlice(2, 4),
+			combine: set.Set[int].SymmetricDifference,
+			want:    newSlice(1, 3, 4),
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			s, other := newSet(tc.s...), newSet(tc.other...)
+
+			// Act.
+			got := set.SortedValues(tc.combine(s, other))
+
+			// Assert.
+			assert.EqualSf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, tc.want, got)
+
+			assert.EqualSf(t, set.SortedValues(s), tc.s, "\n\n"+
+				"UT Name:  %s (the set is unchanged)\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, tc.s, set.SortedValues(s))
+		})
+	}
+}
+
+// UT: Compare two [set.Set]s.
+func TestSet_Compare(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		s, other   []int
+		wantSubset bool
+		wantEqual  bool
+	}{
+		"An empty set is a subset of any set.": {
+			s: newSlice(), other: newSlice(1), wantSubset: true,
+		},
+		"A set that contains some of the elements of the other set is a subset.": {
+			s: newSlice(1, 3), other: newSlice(1, 2, 3), wantSubset: true,
+		},
+		"A set that contains an element that's NOT present in the other set is NOT a subset.": {
+			s: newSlice(1, 4), other: newSlice(1, 2, 3),
+		},
+		"A set that's larger than the other set is NOT a subset.": {
+			s: newSlice(1, 2, 3), other: newSlice(1, 2),
+		},
+		"Sets that contain the same elements are equal.": {
+			s: newSlice(1, 2, 3), other: newSlice(3, 2, 1), wantSubset: true, wantEqual: true,
+		},
+		"Sets of the same size that contain different elements are NOT equal.": {
+			s: newSlice(1, 2), other: newSlice(1, 3),
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			s, other := newSet(tc.s...), newSet(tc.other...)
+
+			// Act.
+			gotSubset, gotEqual := s.IsSubset(other), s.Equal(other)
+
+			// Assert.
+			assert.Equalf(t, gotSubset, tc.wantSubset, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %t.\033[0m\n"+
+				"\033[31mActual:   %t.\033[0m\n\n", tcName, tc.wantSubset, gotSubset)
+
+			assert.Equalf(t, gotEqual, tc.wantEqual, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %t.\033[0m\n"+
+				"\033[31mActual:   %t.\033[0m\n\n", tcName, tc.wantEqual, gotEqual)
+		})
+	}
+}
+
+// UT: Return all elements from a [set.Set] in ascending order.
+func TestSortedValues(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	s := set.New[string]()
+	s.Add("c")
+	s.Add("a")
+	s.Add("b")
+
+	// Act.
+	got, want := set.SortedValues(s), []string{"a", "b", "c"}
+
+	// Assert.
+	assert.EqualSf(t, got, want, "\n\n"+
+		"UT Name:  When requesting the sorted elements, all the elements are returned in ascending order.\n"+
+		"\033[32mExpected: %v.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", want, got)
+}
+
 var benchmarkOutput int // Output of the benchmark(s). Used to avoid compiler optimizations.
 
 // Benchmark(s): Add elements in a [set.Set] that has NO predefined capacity.
@@ -275,3 +498,14 @@ func newSlice(args ...int) []int {
 
 	return container
 }
+
+// Utility: Return a [set.Set] of integers, containing args.
+func newSet(args ...int) set.Set[int] {
+	s := set.WithCapacity[int](len(args))
+
+	for _, v := range args {
+		s.Add(v)
+	}
+
+	return s
+}
@@ -0,0 +1,177 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "pos" package.
+package pos_test
+
+import (
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/pos"
+)
+
+// UT: Get the display width of a rune.
+func TestRuneWidth(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		r    rune
+		want int
+	}{
+		"An ASCII letter occupies 1 column.":                 {r: 'a', want: 1},
+		"A Latin letter with a diacritic occupies 1 column.": {r: 'é', want: 1},
+		"A control character occupies 0 columns.":            {r: '\x1b', want: 0},
+		"A combining mark occupies 0 columns.":               {r: '\u0301', want: 0},
+		"A zero-width space occupies 0 columns.":             {r: '\u200b', want: 0},
+		"A zero-width joiner occupies 0 columns.":            {r: '\u200d', want: 0},
+		"A medial Hangul Jamo vowel occupies 0 columns.":     {r: '\u1161', want: 0},
+		"A CJK ideograph occupies 2 columns.":                {r: '世', want: 2},
+		"A Hangul syllable occupies 2 columns.":              {r: '한', want: 2},
+		"A fullwidth letter occupies 2 columns.":             {r: 'Ａ', want: 2},
+		"An emoji occupies 2 columns.":                       {r: '😀', want: 2},
+		"A halfwidth Katakana letter occupies 1 column.":     {r: 'ｱ', want: 1},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := pos.RuneWidth(tc.r)
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d.\033[0m\n"+
+				"\033[31mActual:   %d.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Get the display width of a string with an invalid tab width.
+func TestDisplayWidthPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	handler := func() {
+		pos.DisplayWidth("\t", 0)
+	}
+
+	// Act / assert.
+	assert.Panicf(t, handler, "\n\n"+
+		"UT Name:  Getting the display width with a tab width less than 1 causes a panic.\n"+
+		"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+		"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+}
+
+// UT: Get the display width of a string.
+func TestDisplayWidth(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		s        string
+		tabWidth int
+		want     int
+	}{
+		"An empty string occupies 0 columns.":                         {s: "", tabWidth: 4, want: 0},
+		"A tab at the start of a line moves to the first tab stop.":   {s: "\t", tabWidth: 4, want: 4},
+		"A tab after some text moves to the next tab stop.":           {s: "ab\tc", tabWidth: 4, want: 5},
+		"A tab at a tab stop moves to the next tab stop.":             {s: "abcd\t", tabWidth: 4, want: 8},
+		"A tab moves to the next multiple of the tab width.":          {s: "a\t", tabWidth: 8, want: 8},
+		"Wide characters occupy 2 columns before a tab.":              {s: "世界\tx", tabWidth: 8, want: 9},
+		"A letter followed by combining marks occupies 1 column.":     {s: "e\u0301\u0302", tabWidth: 4, want: 1},
+		"A decomposed Hangul syllable occupies 2 columns.":            {s: "\u1100\u1161\u11a8", tabWidth: 4, want: 2},
+		"Emoji joined by a zero-width joiner occupy their own width.": {s: "👩\u200d💻", tabWidth: 4, want: 4},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := pos.DisplayWidth(tc.s, tc.tabWidth)
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d.\033[0m\n"+
+				"\033[31mActual:   %d.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Get the display column of a [pos.Position] with an invalid tab width.
+func TestLineIndex_DisplayColumnPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	li := pos.NewLineIndex("\t")
+
+	handler := func() {
+		li.DisplayColumn(li.Position(1), 0)
+	}
+
+	// Act / assert.
+	assert.Panicf(t, handler, "\n\n"+
+		"UT Name:  Getting the display column with a tab width less than 1 causes a panic.\n"+
+		"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+		"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+}
+
+// UT: Get the display column of a [pos.Position].
+func TestLineIndex_DisplayColumn(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	li := pos.NewLineIndex("x\r\n\t世é=\r\n")
+
+	for tcName, tc := range map[string]struct {
+		offset int
+		want   int
+	}{
+		"The start of a line is at column 1.":                                    {offset: 3, want: 1},
+		"A position after a tab is at the next tab stop.":                        {offset: 4, want: 5},
+		"A position after a wide character is 2 columns further.":                {offset: 7, want: 7},
+		"A position after a combining mark is at the column of its base.":        {offset: 10, want: 8},
+		"A position in the line terminator is at the end of its line.":           {offset: 12, want: 9},
+		"A position before the line terminator of the first line is at the end.": {offset: 1, want: 2},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := li.DisplayColumn(li.Position(tc.offset), 4)
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d.\033[0m\n"+
+				"\033[31mActual:   %d.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
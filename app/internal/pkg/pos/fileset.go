@@ -0,0 +1,248 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package pos provides data structures and utilities for tracking positions within an input stream or file.
+package pos
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Pos is a compact representation of a location in one of the files of a [FileSet].
+// The files of a [FileSet] occupy consecutive ranges of values, so a single integer identifies both the file and the
+// offset in that file. The zero value is [NoPos].
+type Pos int
+
+// NoPos is the value of a [Pos] that doesn't identify any location.
+const NoPos Pos = 0
+
+// IsValid reports whether p identifies a location.
+func (p Pos) IsValid() bool {
+	return p != NoPos
+}
+
+// FilePosition is a [Position] in a named file.
+type FilePosition struct {
+	// Filename is the name of the file.
+	Filename string
+
+	// Position is the location in the file.
+	Position
+}
+
+// IsValid reports whether the position identifies a location.
+func (fp FilePosition) IsValid() bool {
+	return fp.Line > 0
+}
+
+// String returns the human-readable representation of the position ("Filename:Line:Column"), or "-" if it's NOT
+// valid.
+func (fp FilePosition) String() string {
+	if !fp.IsValid() {
+		return "-"
+	}
+
+	if fp.Filename == "" {
+		return fp.Position.String()
+	}
+
+	return fp.Filename + ":" + fp.Position.String()
+}
+
+// FileSet is a set of files, where each location in a file is identified by a [Pos].
+// A FileSet is safe for concurrent use.
+type FileSet struct {
+	mu    sync.RWMutex
+	base  int                  // The base of the next file that's added.
+	files []*File              // The files, ordered by base.
+	last  atomic.Pointer[File] // The file that was resolved last (a cache).
+}
+
+// NewFileSet returns a new [FileSet] without files.
+func NewFileSet() *FileSet {
+	return &FileSet{base: 1}
+}
+
+// AddFile adds a file with the given name and source to the set, and returns it.
+// The file occupies len(source)+1 values (a [Pos] for each byte, and one for the end of the file).
+// The lines of the file end with a "\n" or a "\r\n" (see [DefaultTerminators]).
+// The set keeps a reference to source (see [File]).
+func (fs *FileSet) AddFile(name, source string) *File {
+	return fs.AddFileWith(name, source, DefaultTerminators)
+}
+
+// AddFileWith adds a file with the given name and source to the set, where lines end with one of the line terminators
+// in terminators, and returns it.
+// The file occupies len(source)+1 values (a [Pos] for each byte, and one for the end of the file).
+// The set keeps a reference to source (see [File]).
+func (fs *FileSet) AddFileWith(name, source string, terminators Terminators) *File {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	f := &File{name: name, source: source, base: fs.base, terminators: terminators}
+	fs.base += len(source) + 1
+	fs.files = append(fs.files, f)
+
+	return f
+}
+
+// Base returns the base of the next file that's added to the set.
+func (fs *FileSet) Base() int {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	return fs.base
+}
+
+// File returns the file that contains p, or <nil> if p isn't part of any file in the set.
+func (fs *FileSet) File(p Pos) *File {
+	if f := fs.last.Load(); f != nil && f.contains(p) {
+		return f
+	}
+
+	fs.mu.RLock()
+	idx, found := slices.BinarySearchFunc(fs.files, p, func(f *File, p Pos) int {
+		return f.base - int(p)
+	})
+
+	if !found {
+		idx--
+	}
+
+	var f *File
+
+	if idx >= 0 && fs.files[idx].contains(p) {
+		f = fs.files[idx]
+	}
+
+	fs.mu.RUnlock()
+
+	if f != nil {
+		fs.last.Store(f)
+	}
+
+	return f
+}
+
+// Resolve returns the location of p, in the file that contains it.
+// The result isn't valid if p isn't part of any file in the set.
+func (fs *FileSet) Resolve(p Pos) FilePosition {
+	if f := fs.File(p); f != nil {
+		return f.FilePosition(p)
+	}
+
+	return FilePosition{}
+}
+
+// File is a file in a [FileSet].
+// The index of the lines of the file is built the first time it's needed.
+//
+// NOTE: A File keeps its source for as long as the file (or its [FileSet]) is reachable, since its [LineIndex] needs
+// the source to count columns (in any [Unit]) and display columns. The index itself adds three entries per line.
+// Resolving a [Pos] finds its line with a binary search, so it costs O(log n) on a line that only holds ASCII. On any
+// other line, it walks that line up to the [Pos] to count the column, which adds the length of the line.
+type File struct {
+	name        string
+	source      string
+	base        int
+	terminators Terminators
+	once        sync.Once
+	index       *LineIndex
+}
+
+// Name returns the name of the file.
+func (f *File) Name() string {
+	return f.name
+}
+
+// Base returns the value of the [Pos] at the start of the file.
+func (f *File) Base() int {
+	return f.base
+}
+
+// Size returns the amount of bytes in the file.
+func (f *File) Size() int {
+	return len(f.source)
+}
+
+// Source returns the contents of the file.
+func (f *File) Source() string {
+	return f.source
+}
+
+// LineCount returns the amount of lines in the file.
+func (f *File) LineCount() int {
+	return f.LineIndex().LineCount()
+}
+
+// LineIndex returns the index of the lines of the file.
+func (f *File) LineIndex() *LineIndex {
+	f.once.Do(func() {
+		f.index = NewLineIndexWith(f.source, f.terminators)
+	})
+
+	return f.index
+}
+
+// Pos returns the [Pos] of the byte at offset in the file.
+// An offset equal to the size of the file is the end of the file.
+// Panics if offset is outside of the file.
+func (f *File) Pos(offset int) Pos {
+	if offset < 0 || offset > len(f.source) {
+		panic("Pos: offset is outside of the file")
+	}
+
+	return Pos(f.base + offset)
+}
+
+// Offset returns the offset in the file of p.
+// Panics if p isn't part of the file.
+func (f *File) Offset(p Pos) int {
+	if !f.contains(p) {
+		panic("Offset: position is outside of the file")
+	}
+
+	return int(p) - f.base
+}
+
+// Position returns the location of p in the file.
+// Columns are counted in the same way as [Terminators.Advance] does.
+// Panics if p isn't part of the file.
+func (f *File) Position(p Pos) Position {
+	return f.LineIndex().Position(f.Offset(p))
+}
+
+// FilePosition returns the location of p in the file, including the name of the file.
+// Panics if p isn't part of the file.
+func (f *File) FilePosition(p Pos) FilePosition {
+	return FilePosition{Filename: f.name, Position: f.Position(p)}
+}
+
+// Reports whether p is part of the file.
+func (f *File) contains(p Pos) bool {
+	return int(p) >= f.base && int(p) <= f.base+len(f.source)
+}
@@ -0,0 +1,235 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "pos" package.
+package pos_test
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/pos"
+)
+
+// UT: Add files to a [pos.FileSet].
+func TestFileSet_AddFile(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	fs := pos.NewFileSet()
+
+	// Act.
+	a, b := fs.AddFile("a.go", "abc"), fs.AddFile("b.go", "")
+	got, want := []int{a.Base(), b.Base(), fs.Base()}, []int{1, 5, 6}
+
+	// Assert.
+	assert.EqualSf(t, got, want, "\n\n"+
+		"UT Name:  Files that are added to a 'FileSet' occupy consecutive ranges (including their end).\n"+
+		"\033[32mExpected: %v.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", want, got)
+}
+
+// UT: Convert between offsets and positions of a [pos.File] that are outside of the file.
+func TestFilePanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, handler := range map[string]func(f *pos.File){
+		"Getting the 'Pos' of a negative offset causes a panic.": func(f *pos.File) {
+			f.Pos(-1)
+		},
+		"Getting the 'Pos' of an offset after the end of the file causes a panic.": func(f *pos.File) {
+			f.Pos(4)
+		},
+		"Getting the offset of a 'Pos' in another file causes a panic.": func(f *pos.File) {
+			f.Offset(pos.Pos(f.Base() + 4))
+		},
+		"Getting the position of 'NoPos' causes a panic.": func(f *pos.File) {
+			f.Position(pos.NoPos)
+		},
+	} {
+		tcName, handler := tcName, handler // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			fs := pos.NewFileSet()
+			f := fs.AddFile("a.go", "abc")
+			fs.AddFile("b.go", "def")
+
+			// Act / assert.
+			assert.Panicf(t, func() { handler(f) }, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+				"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n", tcName)
+		})
+	}
+}
+
+// UT: Resolve the position of a [pos.Pos] in a [pos.FileSet].
+func TestFileSet_Resolve(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	fs := pos.NewFileSet()
+	a := fs.AddFile("a.go", "ab\r\ncé😀d\n")
+	b := fs.AddFile("b.go", "")
+	c := fs.AddFile("c.go", "x\ny")
+
+	for tcName, tc := range map[string]struct {
+		p    pos.Pos
+		want string
+	}{
+		"'NoPos' isn't valid.":                                           {p: pos.NoPos, want: "-"},
+		"A position after the last file isn't valid.":                    {p: pos.Pos(fs.Base()), want: "-"},
+		"The start of the first file is at 1:1.":                         {p: a.Pos(0), want: "a.go:1:1"},
+		"A carriage return doesn't occupy a column.":                     {p: a.Pos(3), want: "a.go:1:3"},
+		"A line feed starts a new line.":                                 {p: a.Pos(4), want: "a.go:2:1"},
+		"A rune that occupies multiple bytes occupies a single column.":  {p: a.Pos(11), want: "a.go:2:4"},
+		"The end of a file that ends with a line feed is on a new line.": {p: a.Pos(a.Size()), want: "a.go:3:1"},
+		"The end of an empty file is at 1:1.":                            {p: b.Pos(0), want: "b.go:1:1"},
+		"A position in the last file is resolved in that file.":          {p: c.Pos(2), want: "c.go:2:1"},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := fs.Resolve(tc.p).String()
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %s.\033[0m\n"+
+				"\033[31mActual:   %s.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Compare the positions that are resolved by a [pos.FileSet] with the positions of [pos.Position.Advance].
+func TestFileSet_ResolveAdvance(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	rnd := rand.New(rand.NewSource(42))
+	alphabet := []rune("ab \r\né😀")
+	fs := pos.NewFileSet()
+	sources := make([]string, 50)
+	files := make([]*pos.File, len(sources))
+
+	for idx := range sources {
+		var sb strings.Builder
+
+		for range rnd.Intn(40) {
+			sb.WriteRune(alphabet[rnd.Intn(len(alphabet))])
+		}
+
+		sources[idx] = sb.String()
+		files[idx] = fs.AddFile(fmt.Sprintf("%d.go", idx), sources[idx])
+	}
+
+	// Act / assert.
+	for idx, source := range sources {
+		want := pos.New()
+
+		for offset := 0; ; {
+			got := fs.Resolve(files[idx].Pos(offset))
+
+			assert.Equalf(t, got.Position, want, "\n\n"+
+				"UT Name:  Resolving offset %d of %q produces the position of advancing over the runes before it.\n"+
+				"\033[32mExpected: %+v.\033[0m\n"+
+				"\033[31mActual:   %+v.\033[0m\n\n", offset, source, want, got.Position)
+
+			if offset == len(source) {
+				break
+			}
+
+			r, width := utf8.DecodeRuneInString(source[offset:])
+			want.Advance(r, width)
+			offset += width
+		}
+	}
+}
+
+// UT: Resolve positions of a [pos.FileSet] concurrently.
+func TestFileSet_ResolveConcurrent(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	fs := pos.NewFileSet()
+	files := []*pos.File{fs.AddFile("a.go", "a\nb\nc"), fs.AddFile("b.go", "d\ne")}
+	results := make([]string, 8)
+
+	// Act.
+	var wg sync.WaitGroup
+
+	for idx := range results {
+		wg.Go(func() {
+			f := files[idx%len(files)]
+			results[idx] = fs.Resolve(f.Pos(f.Size())).String()
+		})
+	}
+
+	wg.Wait()
+
+	// Assert.
+	for idx, got := range results {
+		want := []string{"a.go:3:2", "b.go:2:2"}[idx%len(files)]
+
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  Resolving positions concurrently produces the same positions.\n"+
+			"\033[32mExpected: %s.\033[0m\n"+
+			"\033[31mActual:   %s.\033[0m\n\n", want, got)
+	}
+}
+
+// Benchmark: Resolve positions in a [pos.FileSet] with many files.
+func BenchmarkFileSet_Resolve(b *testing.B) {
+	fs := pos.NewFileSet()
+	source := strings.Repeat("alpha beta\n", 100)
+
+	for idx := range 1000 {
+		fs.AddFile(fmt.Sprintf("%d.go", idx), source)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	positions := make([]pos.Pos, 1024)
+
+	for idx := range positions {
+		positions[idx] = pos.Pos(1 + rnd.Intn(fs.Base()-1))
+	}
+
+	b.ReportAllocs()
+
+	for idx := 0; b.Loop(); idx++ {
+		fs.Resolve(positions[idx%len(positions)])
+	}
+}
@@ -0,0 +1,235 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package pos provides data structures and utilities for tracking positions within an input stream or file.
+package pos
+
+import (
+	"slices"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Unit is the unit in which the columns of a line are counted.
+type Unit int
+
+const (
+	// Runes counts a column for each rune, like [Terminators.Advance] does.
+	Runes Unit = iota
+
+	// UTF8 counts a column for each byte of the UTF-8 encoding of the line.
+	UTF8
+
+	// UTF16 counts a column for each code unit of the UTF-16 encoding of the line.
+	// A rune outside of the Basic Multilingual Plane (such as most emoji) occupies 2 columns.
+	UTF16
+)
+
+// LineIndex holds the location of the lines of a source, and converts the columns of a [Position] between different
+// units (see [Unit]).
+// A line ends before its line terminator.
+type LineIndex struct {
+	source      string
+	terminators Terminators
+	lines       []int         // The offset of the first byte of each line.
+	ends        []Terminators // The terminator that ends each line (0 for the last line).
+	ascii       []bool        // Indicates whether each line only holds ASCII (other than '\r'), a column per byte.
+}
+
+// NewLineIndex returns a [LineIndex] for source, where lines end with a "\n" or a "\r\n" (see [DefaultTerminators]).
+func NewLineIndex(source string) *LineIndex {
+	return NewLineIndexWith(source, DefaultTerminators)
+}
+
+// NewLineIndexWith returns a [LineIndex] for source, where lines end with one of the line terminators in terminators.
+func NewLineIndexWith(source string, terminators Terminators) *LineIndex {
+	li := &LineIndex{source: source, terminators: terminators, lines: []int{0}}
+	prev := rune(0)
+
+	for offset, r := range source {
+		term, breaks := terminators.terminator(prev, r)
+
+		switch {
+		case breaks:
+			li.lines = append(li.lines, offset+utf8.RuneLen(r))
+			li.ends = append(li.ends, term)
+
+		case term != 0:
+			// A "\r\n", where the '\r' already ended the line.
+			li.lines[len(li.lines)-1] = offset + utf8.RuneLen(r)
+			li.ends[len(li.ends)-1] = term
+		}
+
+		prev = r
+	}
+
+	li.ends = append(li.ends, 0)
+	li.ascii = make([]bool, len(li.lines))
+
+	for line := range li.ascii {
+		li.ascii[line] = isASCII(source[li.lines[line]:li.lineEnd(line+1)])
+	}
+
+	return li
+}
+
+// Reports whether text only holds ASCII characters other than '\r', each of which occupies a single column.
+func isASCII(text string) bool {
+	for idx := range len(text) {
+		if text[idx] >= utf8.RuneSelf || text[idx] == '\r' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LineCount returns the amount of lines in the source.
+func (li *LineIndex) LineCount() int {
+	return len(li.lines)
+}
+
+// Line returns the line that contains the byte at offset.
+// An offset equal to the size of the source is part of the last line.
+// Panics if offset is outside of the source.
+func (li *LineIndex) Line(offset int) int {
+	if offset < 0 || offset > len(li.source) {
+		panic("Line: offset is outside of the source")
+	}
+
+	line, found := slices.BinarySearch(li.lines, offset)
+
+	if !found {
+		line--
+	}
+
+	return line + 1
+}
+
+// Terminator returns the line terminator that ends line, or 0 for the last line.
+// Panics if line is outside of the source.
+func (li *LineIndex) Terminator(line int) Terminators {
+	if line < 1 || line > len(li.lines) {
+		panic("Terminator: line is outside of the source")
+	}
+
+	return li.ends[line-1]
+}
+
+// Position returns the [Position] of the byte at offset.
+// It takes O(log n) time on a line that only holds ASCII, and walks the line up to offset otherwise.
+// Panics if offset is outside of the source.
+func (li *LineIndex) Position(offset int) Position {
+	line := li.Line(offset)
+	p := Position{Line: line, Column: 1, Offset: li.lines[line-1]}
+
+	if li.ascii[line-1] && offset <= li.lineEnd(line) {
+		p.Column, p.Offset = 1+offset-p.Offset, offset
+
+		return p
+	}
+
+	for prev := rune(0); p.Offset < offset; {
+		r, size := utf8.DecodeRuneInString(li.source[p.Offset:offset])
+		li.terminators.Advance(&p, prev, r, size)
+		prev = r
+	}
+
+	return p
+}
+
+// Column returns the column of p, counted in unit.
+// The column is computed from the Offset of p. A position in the line terminator is at the end of its line.
+// Panics if p is outside of the source.
+func (li *LineIndex) Column(p Position, unit Unit) int {
+	line := li.Line(p.Offset)
+	column := 1
+
+	for offset, end := li.lines[line-1], min(p.Offset, li.lineEnd(line)); offset < end; {
+		r, size := utf8.DecodeRuneInString(li.source[offset:end])
+		column += li.width(r, size, unit)
+		offset += size
+	}
+
+	return column
+}
+
+// PositionOf returns the [Position] at column (counted in unit) of line.
+// A column after the end of the line resolves to the end of the line, and a column in the middle of a rune resolves
+// to the start of that rune.
+// Panics if line is outside of the source or if column is less than 1.
+func (li *LineIndex) PositionOf(line, column int, unit Unit) Position {
+	if line < 1 || line > len(li.lines) {
+		panic("PositionOf: line is outside of the source")
+	}
+
+	if column < 1 {
+		panic("PositionOf: column must be at least 1")
+	}
+
+	p := Position{Line: line, Column: 1, Offset: li.lines[line-1]}
+	current, end, prev := 1, li.lineEnd(line), rune(0)
+
+	for p.Offset < end {
+		r, size := utf8.DecodeRuneInString(li.source[p.Offset:end])
+
+		if current+li.width(r, size, unit) > column {
+			break
+		}
+
+		current += li.width(r, size, unit)
+		li.terminators.Advance(&p, prev, r, size)
+		prev = r
+	}
+
+	return p
+}
+
+// Returns the offset of the end of line (before its line terminator).
+func (li *LineIndex) lineEnd(line int) int {
+	if line == len(li.lines) {
+		return len(li.source)
+	}
+
+	return li.lines[line] - len(li.ends[line-1].String())
+}
+
+// Returns the amount of columns that r (which occupies size bytes in the source) occupies when counted in unit.
+func (li *LineIndex) width(r rune, size int, unit Unit) int {
+	switch unit {
+	case UTF8:
+		return size
+
+	case UTF16:
+		return utf16.RuneLen(r)
+
+	default:
+		if r == '\r' && li.terminators&CRLF != 0 {
+			return 0
+		}
+
+		return 1
+	}
+}
@@ -0,0 +1,286 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "pos" package.
+package pos_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/pos"
+)
+
+// UT: Use a [pos.LineIndex] with a location that's outside of the source.
+func TestLineIndexPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, handler := range map[string]func(li *pos.LineIndex){
+		"Getting the line of a negative offset causes a panic.": func(li *pos.LineIndex) {
+			li.Line(-1)
+		},
+		"Getting the line of an offset after the source causes a panic.": func(li *pos.LineIndex) {
+			li.Line(4)
+		},
+		"Getting the position of line 0 causes a panic.": func(li *pos.LineIndex) {
+			li.PositionOf(0, 1, pos.UTF16)
+		},
+		"Getting the position of a line after the source causes a panic.": func(li *pos.LineIndex) {
+			li.PositionOf(3, 1, pos.UTF16)
+		},
+		"Getting the position of column 0 causes a panic.": func(li *pos.LineIndex) {
+			li.PositionOf(1, 0, pos.UTF16)
+		},
+	} {
+		tcName, handler := tcName, handler // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			li := pos.NewLineIndex("a\nb")
+
+			// Act / assert.
+			assert.Panicf(t, func() { handler(li) }, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+				"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n", tcName)
+		})
+	}
+}
+
+// UT: Count the lines of a [pos.LineIndex].
+func TestLineIndex_LineCount(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		source string
+		want   int
+	}{
+		"An empty source has a single line.":                           {source: "", want: 1},
+		"A source without line terminators has a single line.":         {source: "abc", want: 1},
+		"A source that ends with a line terminator has an empty line.": {source: "a\r\nb\n", want: 3},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := pos.NewLineIndex(tc.source).LineCount()
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d.\033[0m\n"+
+				"\033[31mActual:   %d.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Get the column of a [pos.Position] in different units.
+func TestLineIndex_Column(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	li := pos.NewLineIndex("aé😀b\r\nx")
+
+	for tcName, tc := range map[string]struct {
+		offset int
+		want   [3]int // The column in runes, UTF-8 and UTF-16.
+	}{
+		"The start of a line is at column 1 in every unit.": {
+			offset: 0, want: [3]int{1, 1, 1},
+		},
+		"A rune of 2 bytes occupies a single UTF-16 code unit.": {
+			offset: 3, want: [3]int{3, 4, 3},
+		},
+		"A rune outside of the Basic Multilingual Plane occupies 2 UTF-16 code units.": {
+			offset: 7, want: [3]int{4, 8, 5},
+		},
+		"The end of a line is before the carriage return.": {
+			offset: 8, want: [3]int{5, 9, 6},
+		},
+		"A position between a carriage return and a line feed is at the end of the line.": {
+			offset: 9, want: [3]int{5, 9, 6},
+		},
+		"The start of the next line is at column 1 in every unit.": {
+			offset: 10, want: [3]int{1, 1, 1},
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			p := li.Position(tc.offset)
+			got := [3]int{li.Column(p, pos.Runes), li.Column(p, pos.UTF8), li.Column(p, pos.UTF16)}
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Get the [pos.Position] at a column in a given unit.
+func TestLineIndex_PositionOf(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	li := pos.NewLineIndex("aé😀b\r\nx")
+
+	for tcName, tc := range map[string]struct {
+		line, column int
+		unit         pos.Unit
+		want         pos.Position
+	}{
+		"A UTF-16 column after a surrogate pair resolves to the rune after it.": {
+			line: 1, column: 5, unit: pos.UTF16, want: pos.Position{Line: 1, Column: 4, Offset: 7},
+		},
+		"A UTF-16 column between the halves of a surrogate pair resolves to the start of the rune.": {
+			line: 1, column: 4, unit: pos.UTF16, want: pos.Position{Line: 1, Column: 3, Offset: 3},
+		},
+		"A UTF-8 column in the middle of a rune resolves to the start of the rune.": {
+			line: 1, column: 3, unit: pos.UTF8, want: pos.Position{Line: 1, Column: 2, Offset: 1},
+		},
+		"A rune column resolves to the rune at that column.": {
+			line: 1, column: 4, unit: pos.Runes, want: pos.Position{Line: 1, Column: 4, Offset: 7},
+		},
+		"A column after the end of the line resolves to the end of the line (before the carriage return).": {
+			line: 1, column: 100, unit: pos.UTF16, want: pos.Position{Line: 1, Column: 5, Offset: 8},
+		},
+		"A column in the last line is resolved in that line.": {
+			line: 2, column: 2, unit: pos.UTF8, want: pos.Position{Line: 2, Column: 2, Offset: 11},
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := li.PositionOf(tc.line, tc.column, tc.unit)
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %+v.\033[0m\n"+
+				"\033[31mActual:   %+v.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Convert the columns of random sources back and forth.
+func TestLineIndex_RoundTrip(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	rnd := rand.New(rand.NewSource(43))
+	alphabet := []string{"a", " ", "é", "€", "😀", "\n", "\r\n"}
+
+	for range 200 {
+		var sb strings.Builder
+
+		for range rnd.Intn(30) {
+			sb.WriteString(alphabet[rnd.Intn(len(alphabet))])
+		}
+
+		source := sb.String()
+		li := pos.NewLineIndex(source)
+
+		// Act / assert.
+		for offset := 0; offset <= len(source); offset++ {
+			if offset < len(source) && !utf8.RuneStart(source[offset]) || offset > 0 && source[offset-1] == '\r' {
+				continue
+			}
+
+			want := li.Position(offset)
+
+			for _, unit := range []pos.Unit{pos.Runes, pos.UTF8, pos.UTF16} {
+				got := li.PositionOf(want.Line, li.Column(want, unit), unit)
+
+				assert.Equalf(t, got, want, "\n\n"+
+					"UT Name:  Converting offset %d of %q to a column in unit %d and back produces the same position.\n"+
+					"\033[32mExpected: %+v.\033[0m\n"+
+					"\033[31mActual:   %+v.\033[0m\n\n", offset, source, unit, want, got)
+			}
+		}
+	}
+}
+
+// UT: Compare the positions of a [pos.LineIndex] with the ones of advancing over the runes, for each set of line
+// terminators.
+func TestLineIndex_PositionDifferential(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for _, terminators := range []pos.Terminators{
+		pos.DefaultTerminators, pos.LF, pos.CRLF, pos.CR | pos.CRLF, pos.CR | pos.LF, pos.AllTerminators,
+	} {
+		// Arrange.
+		rnd := rand.New(rand.NewSource(int64(terminators)))
+		alphabet := []rune("ab \t\r\n é")
+
+		for range 100 {
+			text := make([]rune, rnd.Intn(40))
+
+			for idx := range text {
+				if rnd.Intn(4) == 0 {
+					text[idx] = alphabet[rnd.Intn(len(alphabet))]
+				} else {
+					text[idx] = alphabet[rnd.Intn(3)]
+				}
+			}
+
+			source := string(text)
+			li := pos.NewLineIndexWith(source, terminators)
+			want, prev := pos.New(), rune(0)
+
+			// Act / assert.
+			for offset := 0; ; {
+				got := li.Position(offset)
+
+				assert.Equalf(t, got, want, "\n\n"+
+					"UT Name:  The position of offset %d of %q (terminators %05b) is the one of advancing over the runes before it.\n"+
+					"\033[32mExpected: %+v.\033[0m\n"+
+					"\033[31mActual:   %+v.\033[0m\n\n", offset, source, terminators, want, got)
+
+				if offset == len(source) {
+					break
+				}
+
+				r, width := utf8.DecodeRuneInString(source[offset:])
+				terminators.Advance(&want, prev, r, width)
+				offset, prev = offset+width, r
+			}
+		}
+	}
+}
@@ -35,6 +35,9 @@ type Position struct {
 
 	// Column is the actual column number. The first column is 1.
 	Column int
+
+	// Offset is the amount of bytes before the position. The first byte is at offset 0.
+	Offset int
 }
 
 // New returns a new [Position].
@@ -45,16 +48,24 @@ func New() Position {
 	}
 }
 
-// Advance increases the Line (if required) and Column values of the position based on r.
-func (p *Position) Advance(r rune) {
-	if r != '\r' && r != '\n' {
-		p.Column += 1
-	}
+// Advance increases the Line (if required) and Column values of the position based on r, and increases the Offset by
+// width, which is the amount of bytes that r occupies in the input (e.g. its UTF-8 encoded length).
+// A line ends with a "\n" or a "\r\n" (see [DefaultTerminators]). To use other line terminators, use
+// [Terminators.Advance].
+func (p *Position) Advance(r rune, width int) {
+	DefaultTerminators.Advance(p, 0, r, width)
+}
 
-	if r == '\n' {
-		p.Column = 1
-		p.Line += 1
-	}
+// Compare returns a negative number if p is located before other, a positive number if p is located after other and 0
+// if both are located at the same offset.
+// Positions are compared by their Offset, so both positions must be part of the same input.
+func (p Position) Compare(other Position) int {
+	return p.Offset - other.Offset
+}
+
+// Before reports whether p is located before other.
+func (p Position) Before(other Position) bool {
+	return p.Compare(other) < 0
 }
 
 // String returns the human-readable representation of the position.
@@ -65,7 +65,7 @@ func TestPos_Advance(t *testing.T) {
 		p := pos.New()
 
 		// Act.
-		(&p).Advance(' ')
+		(&p).Advance(' ', 1)
 
 		// Assert.
 		gotLine, gotCol, wantLine, wantCol := p.Line, p.Column, 1, 2
@@ -88,9 +88,9 @@ func TestPos_Advance(t *testing.T) {
 		p := pos.New()
 
 		// Act.
-		(&p).Advance(' ')
-		(&p).Advance(' ')
-		(&p).Advance(' ')
+		(&p).Advance(' ', 1)
+		(&p).Advance(' ', 1)
+		(&p).Advance(' ', 1)
 
 		// Assert.
 		gotLine, gotCol, wantLine, wantCol := p.Line, p.Column, 1, 4
@@ -113,7 +113,7 @@ func TestPos_Advance(t *testing.T) {
 		p := pos.New()
 
 		// Act.
-		(&p).Advance('\n')
+		(&p).Advance('\n', 1)
 
 		// Assert.
 		gotLine, gotCol, wantLine, wantCol := p.Line, p.Column, 2, 1
@@ -136,9 +136,9 @@ func TestPos_Advance(t *testing.T) {
 		p := pos.New()
 
 		// Act.
-		(&p).Advance('\n')
-		(&p).Advance('\n')
-		(&p).Advance('\n')
+		(&p).Advance('\n', 1)
+		(&p).Advance('\n', 1)
+		(&p).Advance('\n', 1)
 
 		// Assert.
 		gotLine, gotCol, wantLine, wantCol := p.Line, p.Column, 4, 1
@@ -161,17 +161,17 @@ func TestPos_Advance(t *testing.T) {
 		p := pos.New()
 
 		// Act: Consume the runes of the string "Hello\nWorld".
-		(&p).Advance('H')
-		(&p).Advance('e')
-		(&p).Advance('l')
-		(&p).Advance('l')
-		(&p).Advance('o')
-		(&p).Advance('\n')
-		(&p).Advance('w')
-		(&p).Advance('o')
-		(&p).Advance('r')
-		(&p).Advance('l')
-		(&p).Advance('d')
+		(&p).Advance('H', 1)
+		(&p).Advance('e', 1)
+		(&p).Advance('l', 1)
+		(&p).Advance('l', 1)
+		(&p).Advance('o', 1)
+		(&p).Advance('\n', 1)
+		(&p).Advance('w', 1)
+		(&p).Advance('o', 1)
+		(&p).Advance('r', 1)
+		(&p).Advance('l', 1)
+		(&p).Advance('d', 1)
 
 		// Assert.
 		gotLine, gotCol, wantLine, wantCol := p.Line, p.Column, 2, 6
@@ -188,6 +188,79 @@ func TestPos_Advance(t *testing.T) {
 	})
 }
 
+// UT: Advance a [pos.Position] over runes that occupy multiple bytes.
+func TestPos_AdvanceOffset(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	p := pos.New()
+
+	// Act: Consume the runes of the string "é\n😀".
+	(&p).Advance('é', 2)
+	(&p).Advance('\n', 1)
+	(&p).Advance('😀', 4)
+
+	// Assert.
+	got, want := p, pos.Position{Line: 2, Column: 2, Offset: 7}
+
+	assert.Equalf(t, got, want, "\n\n"+
+		"UT Name:  When consuming runes, the 'Offset' should be increased with their width.\n"+
+		"\033[32mExpected: %+v.\033[0m\n"+
+		"\033[31mActual:   %+v.\033[0m\n\n", want, got)
+}
+
+// UT: Compare two [pos.Position] values.
+func TestPos_Compare(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		p, other   pos.Position
+		wantSign   int
+		wantBefore bool
+	}{
+		"A position is located before a position with a higher offset.": {
+			p:          pos.Position{Line: 1, Column: 3, Offset: 2},
+			other:      pos.Position{Line: 2, Column: 1, Offset: 4},
+			wantSign:   -1,
+			wantBefore: true,
+		},
+		"A position is located after a position with a lower offset.": {
+			p:        pos.Position{Line: 2, Column: 1, Offset: 4},
+			other:    pos.Position{Line: 1, Column: 3, Offset: 2},
+			wantSign: 1,
+		},
+		"A position is located at a position with the same offset.": {
+			p:     pos.Position{Line: 1, Column: 3, Offset: 2},
+			other: pos.Position{Line: 1, Column: 3, Offset: 2},
+		},
+		"A position after a '\r' is located after the position before it.": {
+			p:        pos.Position{Line: 1, Column: 2, Offset: 2},
+			other:    pos.Position{Line: 1, Column: 2, Offset: 1},
+			wantSign: 1,
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			gotSign, gotBefore := sign(tc.p.Compare(tc.other)), tc.p.Before(tc.other)
+
+			// Assert.
+			assert.Equalf(t, gotSign, tc.wantSign, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %d.\033[0m\n"+
+				"\033[31mActual:   %d.\033[0m\n\n", tcName, tc.wantSign, gotSign)
+
+			assert.Equalf(t, gotBefore, tc.wantBefore, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %t.\033[0m\n"+
+				"\033[31mActual:   %t.\033[0m\n\n", tcName, tc.wantBefore, gotBefore)
+		})
+	}
+}
+
 // UT: Get the human-readable representation of a [pos.Position].
 func TestPos_String(t *testing.T) {
 	t.Parallel() // Enable parallel execution.
@@ -196,17 +269,17 @@ func TestPos_String(t *testing.T) {
 	p := pos.New()
 
 	// Act: Consume the runes of the string "Hello\nWorld".
-	(&p).Advance('H')
-	(&p).Advance('e')
-	(&p).Advance('l')
-	(&p).Advance('l')
-	(&p).Advance('o')
-	(&p).Advance('\n')
-	(&p).Advance('w')
-	(&p).Advance('o')
-	(&p).Advance('r')
-	(&p).Advance('l')
-	(&p).Advance('d')
+	(&p).Advance('H', 1)
+	(&p).Advance('e', 1)
+	(&p).Advance('l', 1)
+	(&p).Advance('l', 1)
+	(&p).Advance('o', 1)
+	(&p).Advance('\n', 1)
+	(&p).Advance('w', 1)
+	(&p).Advance('o', 1)
+	(&p).Advance('r', 1)
+	(&p).Advance('l', 1)
+	(&p).Advance('d', 1)
 
 	// Assert.
 	got, want := p.String(), "2:6"
@@ -216,3 +289,17 @@ func TestPos_String(t *testing.T) {
 		"\033[32mExpected: %v.\033[0m\n"+
 		"\033[31mActual:   %v.\033[0m\n\n", want, got)
 }
+
+// Returns -1, 0 or 1 depending on the sign of n.
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+
+	case n > 0:
+		return 1
+
+	default:
+		return 0
+	}
+}
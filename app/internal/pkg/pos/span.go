@@ -34,3 +34,47 @@ type Span struct {
 	// End if the end [Position].
 	End Position
 }
+
+// Len returns the amount of bytes in the span.
+func (s Span) Len() int {
+	return s.End.Offset - s.Start.Offset
+}
+
+// Contains reports whether p is located in the span.
+// Since a span is half-open, its End isn't part of it.
+func (s Span) Contains(p Position) bool {
+	return !p.Before(s.Start) && p.Before(s.End)
+}
+
+// Overlaps reports whether the span and other have at least one byte in common.
+// An empty span doesn't overlap with any span.
+func (s Span) Overlaps(other Span) bool {
+	if !s.Start.Before(s.End) || !other.Start.Before(other.End) {
+		return false
+	}
+
+	return s.Start.Before(other.End) && other.Start.Before(s.End)
+}
+
+// Union returns the smallest span that contains both the span and other.
+func (s Span) Union(other Span) Span {
+	if other.Start.Before(s.Start) {
+		s.Start = other.Start
+	}
+
+	if s.End.Before(other.End) {
+		s.End = other.End
+	}
+
+	return s
+}
+
+// Text returns the part of source at the span.
+// Panics if the span isn't part of source.
+func (s Span) Text(source string) string {
+	if s.Start.Offset < 0 || s.End.Offset > len(source) || s.End.Before(s.Start) {
+		panic("Text: span is outside of the source")
+	}
+
+	return source[s.Start.Offset:s.End.Offset]
+}
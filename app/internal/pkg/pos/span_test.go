@@ -0,0 +1,227 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "pos" package.
+package pos_test
+
+import (
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/pos"
+)
+
+// UT: Get the length of a [pos.Span].
+func TestSpan_Len(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	s := spanOf("a😀\nb", 1, 6)
+
+	// Act.
+	got, want := s.Len(), 5
+
+	// Assert.
+	assert.Equalf(t, got, want, "\n\n"+
+		"UT Name:  The length of a 'Span' is the amount of bytes in it.\n"+
+		"\033[32mExpected: %d.\033[0m\n"+
+		"\033[31mActual:   %d.\033[0m\n\n", want, got)
+}
+
+// UT: Verify if a [pos.Span] contains a [pos.Position].
+func TestSpan_Contains(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		span   pos.Span
+		offset int
+		want   bool
+	}{
+		"A span contains its start.": {
+			span: spanOf("abcd", 1, 3), offset: 1, want: true,
+		},
+		"A span contains a position before its end.": {
+			span: spanOf("abcd", 1, 3), offset: 2, want: true,
+		},
+		"A span doesn't contain its end.": {
+			span: spanOf("abcd", 1, 3), offset: 3,
+		},
+		"A span doesn't contain a position before its start.": {
+			span: spanOf("abcd", 1, 3), offset: 0,
+		},
+		"An empty span doesn't contain any position.": {
+			span: spanOf("abcd", 2, 2), offset: 2,
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := tc.span.Contains(spanOf("abcd", tc.offset, tc.offset).Start)
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %t.\033[0m\n"+
+				"\033[31mActual:   %t.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Verify if two [pos.Span] values overlap.
+func TestSpan_Overlaps(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		span, other pos.Span
+		want        bool
+	}{
+		"Spans that share a byte overlap.": {
+			span: spanOf("abcdef", 1, 3), other: spanOf("abcdef", 2, 5), want: true,
+		},
+		"A span overlaps with a span that it contains.": {
+			span: spanOf("abcdef", 0, 6), other: spanOf("abcdef", 2, 3), want: true,
+		},
+		"Adjacent spans don't overlap.": {
+			span: spanOf("abcdef", 1, 3), other: spanOf("abcdef", 3, 5),
+		},
+		"An empty span doesn't overlap with a span that contains its position.": {
+			span: spanOf("abcdef", 2, 2), other: spanOf("abcdef", 1, 3),
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got, gotReversed := tc.span.Overlaps(tc.other), tc.other.Overlaps(tc.span)
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %t.\033[0m\n"+
+				"\033[31mActual:   %t.\033[0m\n\n", tcName, tc.want, got)
+
+			assert.Equalf(t, gotReversed, tc.want, "\n\n"+
+				"UT Name:  %s (reversed)\n"+
+				"\033[32mExpected: %t.\033[0m\n"+
+				"\033[31mActual:   %t.\033[0m\n\n", tcName, tc.want, gotReversed)
+		})
+	}
+}
+
+// UT: Get the union of two [pos.Span] values.
+func TestSpan_Union(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		span, other, want pos.Span
+	}{
+		"The union of overlapping spans covers both.": {
+			span: spanOf("ab\ncdef", 1, 4), other: spanOf("ab\ncdef", 3, 6), want: spanOf("ab\ncdef", 1, 6),
+		},
+		"The union of disjoint spans covers the gap between them.": {
+			span: spanOf("ab\ncdef", 5, 6), other: spanOf("ab\ncdef", 0, 1), want: spanOf("ab\ncdef", 0, 6),
+		},
+		"The union of a span and a span that it contains is the span itself.": {
+			span: spanOf("ab\ncdef", 0, 6), other: spanOf("ab\ncdef", 2, 3), want: spanOf("ab\ncdef", 0, 6),
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Act.
+			got := tc.span.Union(tc.other)
+
+			// Assert.
+			assert.Equalf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %+v.\033[0m\n"+
+				"\033[31mActual:   %+v.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Get the text of a [pos.Span] that's outside of the source.
+func TestSpan_TextPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	handler := func() {
+		spanOf("abcd", 1, 4).Text("abc")
+	}
+
+	// Act / assert.
+	assert.Panicf(t, handler, "\n\n"+
+		"UT Name:  Getting the text of a 'Span' that's outside of the source causes a panic.\n"+
+		"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+		"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+}
+
+// UT: Get the text of a [pos.Span].
+func TestSpan_Text(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	source := "a😀\nbé"
+	s := spanOf(source, 1, 8)
+
+	// Act.
+	got, want := s.Text(source), "😀\nbé"
+
+	// Assert.
+	assert.Equalf(t, got, want, "\n\n"+
+		"UT Name:  The text of a 'Span' is the part of the source at it.\n"+
+		"\033[32mExpected: %q.\033[0m\n"+
+		"\033[31mActual:   %q.\033[0m\n\n", want, got)
+}
+
+// Returns the span between the byte offsets start and end of source.
+func spanOf(source string, start, end int) pos.Span {
+	span := pos.Span{Start: pos.New(), End: pos.New()}
+
+	for offset, r := range source {
+		if offset == start {
+			span.Start = span.End
+		}
+
+		if offset == end {
+			return span
+		}
+
+		span.End.Advance(r, len(string(r)))
+	}
+
+	if start == len(source) {
+		span.Start = span.End
+	}
+
+	return span
+}
@@ -0,0 +1,128 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package pos provides data structures and utilities for tracking positions within an input stream or file.
+package pos
+
+// Terminators is a set of line terminators, which decides where a line ends.
+type Terminators uint8
+
+const (
+	// LF is a line feed ("\n").
+	LF Terminators = 1 << iota
+
+	// CRLF is a carriage return, followed by a line feed ("\r\n").
+	CRLF
+
+	// CR is a carriage return ("\r"), as used by classic Mac OS.
+	CR
+
+	// LS is the Unicode line separator (U+2028).
+	LS
+
+	// PS is the Unicode paragraph separator (U+2029).
+	PS
+)
+
+const (
+	// DefaultTerminators is the set of line terminators that's used by [Position.Advance].
+	// A carriage return that isn't followed by a line feed doesn't end a line (nor does it occupy a column).
+	DefaultTerminators = LF | CRLF
+
+	// AllTerminators is the set of all line terminators.
+	AllTerminators = LF | CRLF | CR | LS | PS
+)
+
+// Advance advances p over r (which occupies width bytes in the input), where prev is the rune before r (or 0 at the
+// start of the input), according to the line terminators in t.
+// A terminator moves p to the start of the next line. When both CR and CRLF are part of t, a "\r\n" moves to the next
+// line at the '\r', and the '\n' doesn't move p any further. When CRLF is part of t (but CR isn't), a '\r' doesn't
+// occupy a column.
+func (t Terminators) Advance(p *Position, prev, r rune, width int) {
+	p.Offset += width
+
+	term, breaks := t.terminator(prev, r)
+
+	switch {
+	case breaks:
+		p.Line += 1
+		p.Column = 1
+
+	case term != 0, r == '\r' && t&CRLF != 0:
+		// The rest of a terminator that already moved to the next line, or the start of a "\r\n".
+
+	default:
+		p.Column += 1
+	}
+}
+
+// String returns the human-readable representation of the terminator (e.g. "\r\n" for CRLF), or "" if t isn't a
+// single terminator.
+func (t Terminators) String() string {
+	switch t {
+	case LF:
+		return "\n"
+
+	case CRLF:
+		return "\r\n"
+
+	case CR:
+		return "\r"
+
+	case LS:
+		return "\u2028"
+
+	case PS:
+		return "\u2029"
+
+	default:
+		return ""
+	}
+}
+
+// Returns the terminator in t that's completed by r, where prev is the rune before r (or 0 if r doesn't complete a
+// terminator), and whether r starts a new line.
+// A '\n' that completes a "\r\n" doesn't start a new line if the '\r' already did (when CR is part of t).
+func (t Terminators) terminator(prev, r rune) (Terminators, bool) {
+	switch {
+	case r == '\n' && prev == '\r' && t&CRLF != 0:
+		return CRLF, t&CR == 0
+
+	case r == '\n' && t&LF != 0:
+		return LF, true
+
+	case r == '\r' && t&CR != 0:
+		return CR, true
+
+	case r == '\u2028' && t&LS != 0:
+		return LS, true
+
+	case r == '\u2029' && t&PS != 0:
+		return PS, true
+
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,203 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "pos" package.
+package pos_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/pos"
+)
+
+// UT: Advance a [pos.Position] with different line terminators.
+func TestTerminators_Advance(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		terminators pos.Terminators
+		input       string
+		want        pos.Position
+	}{
+		"By default, a '\\r' that isn't followed by a '\\n' doesn't end a line.": {
+			terminators: pos.DefaultTerminators, input: "a\rb", want: pos.Position{Line: 1, Column: 3, Offset: 3},
+		},
+		"By default, a \"\\r\\n\" ends a line.": {
+			terminators: pos.DefaultTerminators, input: "a\r\nb", want: pos.Position{Line: 2, Column: 2, Offset: 4},
+		},
+		"With CR, a '\\r' ends a line.": {
+			terminators: pos.CR, input: "a\rb\rc", want: pos.Position{Line: 3, Column: 2, Offset: 5},
+		},
+		"With CR and CRLF, a \"\\r\\n\" ends a single line.": {
+			terminators: pos.CR | pos.CRLF, input: "a\r\nb\rc", want: pos.Position{Line: 3, Column: 2, Offset: 6},
+		},
+		"With CR and LF (but NOT CRLF), a \"\\r\\n\" ends two lines.": {
+			terminators: pos.CR | pos.LF, input: "a\r\nb", want: pos.Position{Line: 3, Column: 2, Offset: 4},
+		},
+		"With CRLF (but NOT LF), a '\\n' that isn't preceded by a '\\r' occupies a column.": {
+			terminators: pos.CRLF, input: "a\nb\r\nc", want: pos.Position{Line: 2, Column: 2, Offset: 6},
+		},
+		"With LF (but NOT CRLF), a '\\r' occupies a column.": {
+			terminators: pos.LF, input: "a\r\nb", want: pos.Position{Line: 2, Column: 2, Offset: 4},
+		},
+		"With LS and PS, the Unicode separators end a line.": {
+			terminators: pos.LS | pos.PS, input: "a\u2028b\u2029c\nd", want: pos.Position{Line: 3, Column: 4, Offset: 11},
+		},
+		"Without LS and PS, the Unicode separators occupy a column.": {
+			terminators: pos.DefaultTerminators, input: "a\u2028b\u2029c", want: pos.Position{Line: 1, Column: 6, Offset: 9},
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			p, prev := pos.New(), rune(0)
+
+			// Act.
+			for _, r := range tc.input {
+				tc.terminators.Advance(&p, prev, r, utf8.RuneLen(r))
+				prev = r
+			}
+
+			// Assert.
+			assert.Equalf(t, p, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %+v.\033[0m\n"+
+				"\033[31mActual:   %+v.\033[0m\n\n", tcName, tc.want, p)
+		})
+	}
+}
+
+// UT: Get the line terminator that ends each line of a [pos.LineIndex].
+func TestLineIndex_Terminator(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		terminators pos.Terminators
+		input       string
+		want        []string
+	}{
+		"By default, lines end with a \"\\n\" or a \"\\r\\n\".": {
+			terminators: pos.DefaultTerminators, input: "a\r\nb\nc\rd", want: []string{"\r\n", "\n", ""},
+		},
+		"With all terminators, each line ends with its own terminator.": {
+			terminators: pos.AllTerminators,
+			input:       "a\r\nb\nc\rd\u2028e\u2029f",
+			want:        []string{"\r\n", "\n", "\r", "\u2028", "\u2029", ""},
+		},
+		"With CR and LF (but NOT CRLF), a \"\\r\\n\" ends a line with '\\r' and an empty line with '\\n'.": {
+			terminators: pos.CR | pos.LF, input: "a\r\nb", want: []string{"\r", "\n", ""},
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			li := pos.NewLineIndexWith(tc.input, tc.terminators)
+
+			// Act.
+			var got []string
+
+			for line := 1; line <= li.LineCount(); line++ {
+				got = append(got, li.Terminator(line).String())
+			}
+
+			// Assert.
+			assert.EqualSf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %q.\033[0m\n"+
+				"\033[31mActual:   %q.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Get the line terminator of a line that's outside of a [pos.LineIndex].
+func TestLineIndex_TerminatorPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	handler := func() {
+		pos.NewLineIndex("a\nb").Terminator(3)
+	}
+
+	// Act / assert.
+	assert.Panicf(t, handler, "\n\n"+
+		"UT Name:  Getting the terminator of a line after the source causes a panic.\n"+
+		"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+		"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+}
+
+// UT: Compare the positions of a [pos.LineIndex] with the positions of [pos.Terminators.Advance].
+func TestLineIndex_PositionTerminators(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	rnd := rand.New(rand.NewSource(45))
+	alphabet := []string{"a", "é", "😀", "\n", "\r", "\r\n", "\u2028", "\u2029"}
+	sets := []pos.Terminators{
+		pos.DefaultTerminators, pos.LF, pos.CR, pos.CRLF, pos.CR | pos.CRLF, pos.CR | pos.LF, pos.AllTerminators,
+	}
+
+	for range 100 {
+		var sb strings.Builder
+
+		for range rnd.Intn(30) {
+			sb.WriteString(alphabet[rnd.Intn(len(alphabet))])
+		}
+
+		source := sb.String()
+
+		for _, terminators := range sets {
+			li := pos.NewLineIndexWith(source, terminators)
+			want, prev := pos.New(), rune(0)
+
+			// Act / assert.
+			for offset, r := range source + "\x00" {
+				got := li.Position(offset)
+
+				assert.Equalf(t, got, want, "\n\n"+
+					"UT Name:  Offset %d of %q (terminators %05b) is at the position of advancing over the runes before it.\n"+
+					"\033[32mExpected: %+v.\033[0m\n"+
+					"\033[31mActual:   %+v.\033[0m\n\n", offset, source, terminators, want, got)
+
+				terminators.Advance(&want, prev, r, utf8.RuneLen(r))
+				prev = r
+			}
+
+			assert.Equalf(t, li.LineCount(), want.Line, "\n\n"+
+				"UT Name:  %q (terminators %05b) has as many lines as advancing over it produces.\n"+
+				"\033[32mExpected: %d.\033[0m\n"+
+				"\033[31mActual:   %d.\033[0m\n\n", source, terminators, want.Line, li.LineCount())
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a mechanism for transforming patterns into a set of tokens.
+package scanner
+
+import (
+	"errors"
+	"slices"
+)
+
+// ErrSkipToken is the error returned by an [Action] to drop its token. The scanner continues with the next token.
+var ErrSkipToken = errors.New("scanner: skip token")
+
+// Action is a semantic action, which is run for each token that's matched by a pattern (see [WithAction]).
+// It's called with the token (its lexeme and span) before the token is returned, and it may compute the data of the
+// token (e.g., a parsed integer, an unescaped string or an interned identifier) or rewrite its value (e.g., turn an
+// identifier into a keyword).
+// Returning [ErrSkipToken] drops the token. Any other error is recorded as a [Diagnostic] at the span of the token
+// (see [Scanner.Diagnostics]), while the token is kept.
+type Action[S comparable, V any] func(token *Token[S, V]) error
+
+// WithAction returns a [PatternOption] which runs action for each token that's matched by the pattern.
+func WithAction[S comparable, V any](action Action[S, V]) PatternOption[S, V] {
+	return func(p *pattern[S, V]) {
+		p.action = action
+	}
+}
+
+// Diagnostics returns a copy of the diagnostics that are reported by the actions of the patterns so far.
+func (s *Scanner[S, V]) Diagnostics() []Diagnostic {
+	return slices.Clone(s.diagnostics)
+}
+
+// Runs action (if any) for token and returns the resulting token. Returns false if the action drops the token.
+func (s *Scanner[S, V]) act(token Token[S, V], action Action[S, V]) (Token[S, V], bool) {
+	if action == nil {
+		return token, true
+	}
+
+	return s.runAction(token, action)
+}
+
+// Runs action for token and returns the resulting token. Returns false if the action drops the token.
+//
+// NOTE: This is separated from [Scanner.act], since the token escapes to the heap when action is called.
+func (s *Scanner[S, V]) runAction(token Token[S, V], action Action[S, V]) (Token[S, V], bool) {
+	switch err := action(&token); {
+	case err == nil:
+		return token, true
+
+	case errors.Is(err, ErrSkipToken):
+		return token, false
+
+	default:
+		s.diagnostics = append(s.diagnostics, Diagnostic{Span: token.Span, Message: err.Error()})
+
+		return token, true
+	}
+}
@@ -0,0 +1,170 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "scanner" package.
+package scanner_test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/scanner"
+)
+
+// UT: Run the actions of the patterns of a [scanner.Scanner].
+func TestScanner_Action(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	const input = "if x 12\n300 y"
+
+	want := newSlice(
+		"IF(if)@1:1-1:3=<nil>", "IDENT(x)@1:4-1:5=<nil>", "NUMBER(12)@1:6-1:8=12", "NUMBER(300)@2:1-2:4=<nil>",
+		"IDENT(y)@2:5-2:6=<nil>", "EOF()@2:6-2:6=<nil>",
+	)
+
+	wantDiagnostics := newSlice("2:1-2:4: number out of range")
+
+	for tcName, tc := range map[string]struct {
+		scan func(s *scanner.Scanner[rune, string]) []scanner.Token[rune, string]
+	}{
+		"Reading the tokens sequentially runs the actions.": {
+			scan: func(s *scanner.Scanner[rune, string]) []scanner.Token[rune, string] {
+				return readAllTokens(s, scanner.NewSliceReader([]rune(input)))
+			},
+		},
+		"Feeding the input in chunks runs the actions.": {
+			scan: func(s *scanner.Scanner[rune, string]) []scanner.Token[rune, string] {
+				ps := scanner.NewPushScanner(s)
+				tokens := ps.Feed([]rune(input[:6]))
+
+				return append(append(tokens, ps.Feed([]rune(input[6:]))...), ps.Close()...)
+			},
+		},
+		"Scanning the input in parallel runs the actions.": {
+			scan: func(s *scanner.Scanner[rune, string]) []scanner.Token[rune, string] {
+				return s.ScanParallel([]rune(input), 2)
+			},
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			s := newActionScanner()
+
+			// Act.
+			got := formatTokensWithData(tc.scan(s))
+			gotDiagnostics := formatDiagnostics(s.Diagnostics())
+
+			// Assert.
+			assert.EqualSf(t, got, want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, want, got)
+
+			assert.EqualSf(t, gotDiagnostics, wantDiagnostics, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected (diagnostics): %v.\033[0m\n"+
+				"\033[31mActual (diagnostics):   %v.\033[0m\n\n", tcName, wantDiagnostics, gotDiagnostics)
+		})
+	}
+
+	t.Run("Reading the values of the tokens runs the actions.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := newActionScanner()
+		rdr := scanner.NewSliceReader([]rune(input))
+
+		// Act.
+		got := readN(s, rdr, 6)
+		want := newSlice("IF", "IDENT", "NUMBER", "NUMBER", "IDENT", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Reading the values of the tokens runs the actions.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+}
+
+// Returns a [scanner.Scanner] with actions that parse numbers (up to 255), turn the identifier "if" into a keyword and
+// drop whitespace.
+func newActionScanner() *scanner.Scanner[rune, string] {
+	digits := make([]scanner.Fragment[rune, string], 0, 10)
+
+	for r := '0'; r <= '9'; r++ {
+		digits = append(digits, scanner.Literal[rune, string](r))
+	}
+
+	parseNumber := func(token *scanner.Token[rune, string]) error {
+		n, err := strconv.ParseUint(string(token.Lexeme), 10, 8)
+
+		if err != nil {
+			return errors.New("number out of range")
+		}
+
+		token.Data = int(n)
+
+		return nil
+	}
+
+	keyword := func(token *scanner.Token[rune, string]) error {
+		if string(token.Lexeme) == "if" {
+			token.Value = "IF"
+		}
+
+		return nil
+	}
+
+	skip := func(*scanner.Token[rune, string]) error {
+		return scanner.ErrSkipToken
+	}
+
+	return scanner.NewScannerBuilder[rune, string]().
+		Add(scanner.RepeatAtLeast(1, scanner.AnyOf(digits...)), "NUMBER", scanner.WithAction(parseNumber)).
+		Add(scanner.ASCIIIdentifier[string](), "IDENT", scanner.WithAction(keyword)).
+		Add(scanner.RepeatAtLeast(1, scanner.AnyOf(
+			scanner.Literal[rune, string](' '),
+			scanner.Literal[rune, string]('\n'),
+		)), "WS", scanner.WithAction(skip)).
+		Build("ILLEGAL", "EOF")
+}
+
+// Utility: Return the human-readable representation ("VALUE(lexeme)@start-end=data") of tokens.
+func formatTokensWithData[V any](tokens []scanner.Token[rune, V]) []string {
+	formatted := formatTokens(tokens)
+
+	for idx, token := range tokens {
+		formatted[idx] += fmt.Sprintf("=%v", token.Data)
+	}
+
+	return formatted
+}
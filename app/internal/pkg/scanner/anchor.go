@@ -0,0 +1,220 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a mechanism for transforming patterns into a set of tokens.
+package scanner
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/kdeconinck/align/internal/pkg/automata/dfa"
+	"github.com/kdeconinck/align/internal/pkg/automata/nfa"
+	"github.com/kdeconinck/align/internal/pkg/pos"
+)
+
+// ErrMisplacedAnchor is the error returned when a pattern of a [ScannerBuilder] has an anchor that can never hold (e.g.,
+// a [LineStart] after a symbol, or a [LineEnd] before a symbol).
+var ErrMisplacedAnchor = errors.New("scanner: misplaced anchor")
+
+// A [Fragment] that matches an empty sequence of symbols where an anchor holds.
+type fragAnchor[S comparable, V any] struct {
+	anchor nfa.Anchor
+}
+
+// LineStart creates a [Fragment] that matches an empty sequence of symbols at the start of a line (or the input).
+// The fragment only matches at the start of a token (e.g., to match a preprocessor directive or a heading).
+func LineStart[S comparable, V any]() Fragment[S, V] {
+	return fragAnchor[S, V]{anchor: nfa.AnchorLineStart}
+}
+
+// InputStart creates a [Fragment] that matches an empty sequence of symbols at the start of the input.
+// The fragment only matches at the start of a token (e.g., to match a shebang).
+func InputStart[S comparable, V any]() Fragment[S, V] {
+	return fragAnchor[S, V]{anchor: nfa.AnchorInputStart}
+}
+
+// LineEnd creates a [Fragment] that matches an empty sequence of symbols before a line break (or at the end of the
+// input). The fragment only matches at the end of a token, and the line break isn't part of the token.
+func LineEnd[S comparable, V any]() Fragment[S, V] {
+	return fragAnchor[S, V]{anchor: nfa.AnchorLineEnd}
+}
+
+// InputEnd creates a [Fragment] that matches an empty sequence of symbols at the end of the input.
+// The fragment only matches at the end of a token.
+func InputEnd[S comparable, V any]() Fragment[S, V] {
+	return fragAnchor[S, V]{anchor: nfa.AnchorInputEnd}
+}
+
+// Build creates a transition that can only be followed where the anchor holds.
+func (frag fragAnchor[S, V]) Build(machine *nfa.Nfa[S, V], startState *nfa.State[S, V]) *nfa.State[S, V] {
+	return machine.AddAnchor(startState, frag.anchor)
+}
+
+// Reports whether every anchor in frag can hold.
+// A start anchor can only hold at the start of a token, and an end anchor only at the end of a token. The parameters
+// atStart and atEnd report whether frag can be at the start and at the end of a token respectively.
+//
+// NOTE: Fragments that aren't created by this package are assumed to contain no anchors.
+func anchorsHold[S comparable, V any](frag Fragment[S, V], atStart, atEnd bool) bool {
+	switch frag := frag.(type) {
+	case fragAnchor[S, V]:
+		return (frag.anchor&nfa.StartAnchors == 0 || atStart) && (frag.anchor&nfa.EndAnchors == 0 || atEnd)
+
+	case fragSequence[S, V]:
+		for idx, sub := range frag.fragments {
+			subAtStart := atStart && allEmpty(frag.fragments[:idx])
+			subAtEnd := atEnd && allEmpty(frag.fragments[idx+1:])
+
+			if !anchorsHold(sub, subAtStart, subAtEnd) {
+				return false
+			}
+		}
+
+		return true
+
+	case fragAnyOf[S, V]:
+		for _, sub := range frag.fragments {
+			if !anchorsHold(sub, atStart, atEnd) {
+				return false
+			}
+		}
+
+		return true
+
+	case fragRepeat[S, V]:
+		return anchorsHold(frag.fragment, atStart, atEnd)
+
+	default:
+		return true
+	}
+}
+
+// Reports whether frag can match an empty sequence of symbols.
+//
+// NOTE: Fragments that aren't created by this package are assumed to match at least one symbol.
+func matchesEmpty[S comparable, V any](frag Fragment[S, V]) bool {
+	switch frag := frag.(type) {
+	case fragAnchor[S, V]:
+		return true
+
+	case fragSequence[S, V]:
+		return allEmpty(frag.fragments)
+
+	case fragAnyOf[S, V]:
+		return slices.ContainsFunc(frag.fragments, matchesEmpty[S, V])
+
+	case fragRepeat[S, V]:
+		return frag.minOccurence == 0 || matchesEmpty(frag.fragment)
+
+	default:
+		return false
+	}
+}
+
+// Reports whether each fragment in fragments can match an empty sequence of symbols.
+func allEmpty[S comparable, V any](fragments []Fragment[S, V]) bool {
+	for _, frag := range fragments {
+		if !matchesEmpty(frag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns the start anchors that hold at the current position of s.
+func (s *Scanner[S, V]) startAnchors() nfa.Anchor {
+	return startAnchorsAt(s.currentPos)
+}
+
+// Returns the start anchors that hold at p.
+func startAnchorsAt(p pos.Position) nfa.Anchor {
+	switch {
+	case p.Line == 1 && p.Column == 1:
+		return nfa.AnchorLineStart | nfa.AnchorInputStart
+
+	case p.Column == 1:
+		return nfa.AnchorLineStart
+
+	default:
+		return 0
+	}
+}
+
+// Returns the end anchors that hold right before sym, which is the case before the first symbol of a line terminator.
+// When sym is a '\r' that only ends a line if it's followed by '\n' (see [pos.CRLF]), the end anchors depend on the
+// next symbol, and crlf is true instead.
+func (s *Scanner[S, V]) endAnchorsBefore(sym S) (anchors nfa.Anchor, crlf bool) {
+	r, ok := runeOf(sym)
+	terminators := s.lexer.terminators
+
+	switch {
+	case !ok:
+		return 0, false
+
+	case r == '\n' && terminators&pos.LF != 0,
+		r == '\r' && terminators&pos.CR != 0,
+		r == '\u2028' && terminators&pos.LS != 0,
+		r == '\u2029' && terminators&pos.PS != 0:
+		return nfa.AnchorLineEnd, false
+
+	case r == '\r' && terminators&pos.CRLF != 0:
+		return 0, true
+
+	default:
+		return 0, false
+	}
+}
+
+// Records the matches of m that depend on end anchors, after reaching state after consuming length symbols, where
+// the end anchors in holds hold (see [Scanner.collectMatches]).
+func (s *Scanner[S, V]) collectAnchoredMatches(m *matching[S, V], state *dfa.State[S, V], length int, holds nfa.Anchor) {
+	if length == 0 {
+		return
+	}
+
+	var found bool
+
+	for _, accept := range state.AnchoredAccepts() {
+		if accept.Anchors&holds != accept.Anchors {
+			continue
+		}
+
+		candidate := match{length: length, rule: accept.AcceptIdx}
+
+		switch {
+		case s.lexer.rules[accept.AcceptIdx].matcher != nil:
+			m.pending = append(m.pending, candidate)
+
+		case !found:
+			if candidate.isBetterThan(m.best) {
+				m.best = candidate
+			}
+
+			found = true
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "scanner" package.
+package scanner_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/scanner"
+)
+
+// UT: Match patterns with anchors.
+func TestScanner_Anchors(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("Patterns with anchors only match where their anchors hold.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := newAnchorScanner()
+
+		// Act.
+		got := formatTokens(readAllTokens(s, newSliceReader([]rune("#!a #b\n#c . \n.a."))))
+		want := newSlice(
+			"SHEBANG(#!)@1:1-1:3", "IDENT(a)@1:3-1:4", "WS( )@1:4-1:5", "HASH(#)@1:5-1:6", "IDENT(b)@1:6-1:7",
+			"NL(\n)@1:7-2:1", "DIRECTIVE(#c)@2:1-2:3", "WS( )@2:3-2:4", "DOT(.)@2:4-2:5", "TRAIL( )@2:5-2:6",
+			"NL(\n)@2:6-3:1", "DOT(.)@3:1-3:2", "IDENT(a)@3:2-3:3", "END(.)@3:3-3:4", "EOF()@3:4-3:4",
+		)
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Patterns with anchors only match where their anchors hold.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Relexing after removing a line break doesn't reuse a token that depends on it.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := newAnchorScanner()
+		input := []rune("a \n#b")
+		tokens := readAllTokens(s, newSliceReader(input))
+
+		// Act.
+		result, _ := s.Relex(tokens, scanner.Edit[rune]{Span: spanOf(input, 2, 3)})
+		got := formatTokens(result)
+		want := newSlice("IDENT(a)@1:1-1:2", "WS( )@1:2-1:3", "HASH(#)@1:3-1:4", "IDENT(b)@1:4-1:5", "EOF()@1:5-1:5")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Relexing after removing a line break doesn't reuse a token that depends on it.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+}
+
+// UT: Compare the tokens of patterns with anchors, produced by the various ways of scanning.
+func TestScanner_AnchorsDifferential(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	rnd := rand.New(rand.NewSource(40))
+	alphabet := []rune("#!a. \n")
+	randomText := func(size int) []rune {
+		text := make([]rune, size)
+
+		for idx := range text {
+			text[idx] = alphabet[rnd.Intn(len(alphabet))]
+		}
+
+		return text
+	}
+
+	relexer := newAnchorScanner()
+	edited := randomText(30)
+	tokens := readAllTokens(relexer, newSliceReader(edited))
+
+	for range 500 {
+		input := randomText(rnd.Intn(60))
+		want := formatTokensWithLookahead(readAllTokens(newAnchorScanner(), newSliceReader(input)))
+
+		// Act.
+		ps := scanner.NewPushScanner(newAnchorScanner())
+		var pushed []scanner.Token[rune, string]
+
+		for _, r := range input {
+			pushed = append(pushed, ps.Feed([]rune{r})...)
+		}
+
+		pushed = append(pushed, ps.Close()...)
+		gotPushed := formatTokensWithLookahead(pushed)
+		gotParallel := formatTokensWithLookahead(newAnchorScanner().ScanParallel(input, 1+rnd.Intn(4)))
+
+		start := rnd.Intn(len(edited) + 1)
+		end := start + rnd.Intn(len(edited)-start+1)
+		text := randomText(rnd.Intn(4))
+		edit := scanner.Edit[rune]{Span: spanOf(edited, start, end), Text: text}
+		edited = append(append(append([]rune(nil), edited[:start]...), text...), edited[end:]...)
+		tokens, _ = relexer.Relex(tokens, edit)
+		gotRelexed := formatTokensWithLookahead(tokens)
+		wantRelexed := formatTokensWithLookahead(readAllTokens(newAnchorScanner(), newSliceReader(edited)))
+
+		// Assert.
+		assert.EqualSf(t, gotPushed, want, "\n\n"+
+			"UT Name:  Feeding %q symbol by symbol produces the same tokens as scanning it.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", string(input), want, gotPushed)
+
+		assert.EqualSf(t, gotParallel, want, "\n\n"+
+			"UT Name:  Scanning %q in parallel produces the same tokens as scanning it sequentially.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", string(input), want, gotParallel)
+
+		assert.EqualSf(t, gotRelexed, wantRelexed, "\n\n"+
+			"UT Name:  Relexing %q produces the same tokens as scanning it again.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", string(edited), wantRelexed, gotRelexed)
+	}
+}
+
+// UT: Build a [scanner.Scanner] with patterns that have misplaced anchors.
+func TestScannerBuilder_MisplacedAnchor(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	spaces := scanner.RepeatAtLeast(0, scanner.Literal[rune, string](' '))
+
+	for tcName, tc := range map[string]struct {
+		frag scanner.Fragment[rune, string]
+		want error
+	}{
+		"Building a pattern with a start anchor after a symbol returns an error.": {
+			frag: scanner.Sequence(scanner.Literal[rune, string]('a'), scanner.LineStart[rune, string]()),
+			want: scanner.ErrMisplacedAnchor,
+		},
+		"Building a pattern with an end anchor before a symbol returns an error.": {
+			frag: scanner.Sequence(scanner.InputEnd[rune, string](), scanner.Literal[rune, string]('a')),
+			want: scanner.ErrMisplacedAnchor,
+		},
+		"Building a pattern with a misplaced anchor in one of its alternatives returns an error.": {
+			frag: scanner.AnyOf(
+				scanner.Literal[rune, string]('a'),
+				scanner.Sequence(scanner.Literal[rune, string]('b'), scanner.LineStart[rune, string]()),
+			),
+			want: scanner.ErrMisplacedAnchor,
+		},
+		"Building a pattern with a start anchor after a fragment that can be empty succeeds.": {
+			frag: scanner.Sequence(spaces, scanner.LineStart[rune, string](), scanner.Literal[rune, string]('#')),
+		},
+		"Building a pattern with an end anchor before a fragment that can be empty succeeds.": {
+			frag: scanner.Sequence(scanner.Literal[rune, string]('#'), scanner.LineEnd[rune, string](), spaces),
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			builder := scanner.NewScannerBuilder[rune, string]().Add(tc.frag, "ANCHORED")
+
+			// Act.
+			_, got := builder.TryBuild("ILLEGAL", "EOF")
+
+			// Assert.
+			assert.Errorf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// Returns a [scanner.Scanner] with patterns that depend on anchors.
+func newAnchorScanner() *scanner.Scanner[rune, string] {
+	spaces := scanner.RepeatAtLeast(1, scanner.Literal[rune, string](' '))
+
+	return scanner.NewScannerBuilder[rune, string]().
+		Add(scanner.Sequence(scanner.InputStart[rune, string](), scanner.Literal[rune, string]('#', '!')), "SHEBANG").
+		Add(scanner.Sequence(
+			scanner.LineStart[rune, string](), scanner.Literal[rune, string]('#'), scanner.ASCIIIdentifier[string](),
+		), "DIRECTIVE").
+		Add(scanner.Literal[rune, string]('#'), "HASH").
+		Add(scanner.Sequence(spaces, scanner.LineEnd[rune, string]()), "TRAIL").
+		Add(spaces, "WS").
+		Add(scanner.Literal[rune, string]('\n'), "NL").
+		Add(scanner.Sequence(scanner.Literal[rune, string]('.'), scanner.InputEnd[rune, string]()), "END").
+		Add(scanner.Literal[rune, string]('.'), "DOT").
+		Add(scanner.ASCIIIdentifier[string](), "IDENT").
+		Build("ILLEGAL", "EOF")
+}
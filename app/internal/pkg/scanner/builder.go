@@ -27,52 +27,253 @@
 package scanner
 
 import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
 	"github.com/kdeconinck/align/internal/pkg/automata/dfa"
 	"github.com/kdeconinck/align/internal/pkg/automata/nfa"
 	"github.com/kdeconinck/align/internal/pkg/pos"
 )
 
+// DefaultStateLimit is the default maximum amount of states of the automata built by a [ScannerBuilder].
+const DefaultStateLimit = 1_000_000
+
+// ErrStateLimit is the error returned when the patterns of a [ScannerBuilder] require more states than its limit
+// allows (see [ScannerBuilder.SetStateLimit]).
+var ErrStateLimit = errors.New("scanner: state limit exceeded")
+
+// ErrTooManyClasses is the error returned when the patterns of a [ScannerBuilder] use more distinct classes (e.g.,
+// [InTable], [Category]) than the automata support.
+var ErrTooManyClasses = errors.New("scanner: too many distinct classes")
+
 // ScannerBuilder is a tool for constructing a [Scanner] by adding various patterns.
 // S is the type of the symbols in the input (e.g., byte, rune) and  V is the type of the returned value.
 type ScannerBuilder[S comparable, V any] struct {
-	patterns []pattern[S, V]
+	patterns    []pattern[S, V]
+	stateLimit  int
+	terminators pos.Terminators
 }
 
 // Associates a [Fragment] (a regular expression building block) with the value it should return upon a match.
+// When keywords is set, the pattern represents a set of keywords instead (see [ScannerBuilder.AddKeywords]).
 type pattern[S comparable, V any] struct {
 	fragment Fragment[S, V]
 	value    V
+	matcher  Matcher[S]   // Completes the token after the fragment matched (optional).
+	action   Action[S, V] // Runs for each token that's matched (optional).
+	keywords *keywordTrie[S, V]
+	priority int  // Decides which pattern wins if multiple patterns match the same token (higher wins).
+	shortest bool // Indicates whether the pattern matches its shortest match, instead of its longest.
+}
+
+// PatternOption configures a pattern that's added to a [ScannerBuilder] (see [ScannerBuilder.Add]).
+type PatternOption[S comparable, V any] func(p *pattern[S, V])
+
+// WithPriority returns a [PatternOption] which sets the priority of the pattern. When multiple patterns match a token
+// of the same length, the pattern with the highest priority wins. Patterns with the same priority (the default is 0)
+// are ordered by the order in which they're added (the first one wins).
+func WithPriority[S comparable, V any](priority int) PatternOption[S, V] {
+	return func(p *pattern[S, V]) {
+		p.priority = priority
+	}
+}
+
+// WithShortestMatch returns a [PatternOption] which makes the pattern non-greedy: the pattern stops matching at its
+// first (shortest) match, instead of its longest (e.g., to end a comment at the first terminator). The longest match
+// among all the patterns still wins.
+func WithShortestMatch[S comparable, V any]() PatternOption[S, V] {
+	return func(p *pattern[S, V]) {
+		p.shortest = true
+	}
 }
 
 // NewScannerBuilder creates a new, empty [ScannerBuilder].
 func NewScannerBuilder[S comparable, V any]() *ScannerBuilder[S, V] {
-	return &ScannerBuilder[S, V]{}
+	return &ScannerBuilder[S, V]{
+		stateLimit:  DefaultStateLimit,
+		terminators: pos.DefaultTerminators,
+	}
+}
+
+// SetStateLimit sets the maximum amount of states of each automaton built by the builder. A limit of 0 means that
+// there's no limit. It returns the builder itself for method chaining.
+// The limit protects against patterns (such as a large bounded repetition of a complex fragment) that would otherwise
+// exhaust memory.
+// The states for the combinations of classes (such as [InTable] and [Category]) are constructed while scanning, and
+// count towards the same limit (see [Lexer.Err]).
+func (builder *ScannerBuilder[S, V]) SetStateLimit(limit int) *ScannerBuilder[S, V] {
+	builder.stateLimit = limit
+	return builder
+}
+
+// SetLineTerminators sets the line terminators that decide where a line ends, both for the location of the tokens and
+// for the anchors of the patterns (see [LineStart] and [LineEnd]). The default is [pos.DefaultTerminators]. It returns
+// the builder itself for method chaining.
+// Panics if S is byte and terminators contains [pos.LS] or [pos.PS], since a single byte can't hold those runes.
+func (builder *ScannerBuilder[S, V]) SetLineTerminators(terminators pos.Terminators) *ScannerBuilder[S, V] {
+	if _, ok := any(*new(S)).(byte); ok && terminators&(pos.LS|pos.PS) != 0 {
+		panic("SetLineTerminators: LS and PS require symbols of type rune")
+	}
+
+	builder.terminators = terminators
+	return builder
+}
+
+// Add appends a new pattern to the builder. It takes a [Fragment] (the pattern to match), the value to return on a
+// successful match and the options that configure the pattern (if any). It returns the builder itself for method
+// chaining.
+func (builder *ScannerBuilder[S, V]) Add(fragment Fragment[S, V], value V, options ...PatternOption[S, V]) *ScannerBuilder[S, V] {
+	p := pattern[S, V]{fragment: fragment, value: value}
+
+	for _, option := range options {
+		option(&p)
+	}
+
+	builder.patterns = append(builder.patterns, p)
+	return builder
 }
 
-// Add appends a new pattern to the builder. It takes a [Fragment] (the pattern to match) and the value to return on a
-// successful match. It returns the builder itself for method chaining.
-func (builder *ScannerBuilder[S, V]) Add(fragment Fragment[S, V], value V) *ScannerBuilder[S, V] {
-	builder.patterns = append(builder.patterns, pattern[S, V]{fragment: fragment, value: value})
+// AddKeywords appends a set of keywords to the builder, where each keyword is mapped to the value to return on a
+// successful match. All keywords are built into a single trie with shared prefixes, which keeps the automaton small,
+// even for language-sized keyword lists. It returns the builder itself for method chaining.
+// Panics if a keyword is empty or if S isn't byte or rune.
+func (builder *ScannerBuilder[S, V]) AddKeywords(keywords map[string]V) *ScannerBuilder[S, V] {
+	builder.patterns = append(builder.patterns, pattern[S, V]{keywords: newKeywordTrie[S](keywords)})
 	return builder
 }
 
 // Build finalizes the construction, converting all added patterns into a fully functional and optimized [Scanner].
 // The value to return when NO pattern matches is defaultValue.
 // The value to return when the input is exhausted on finalValue.
+// Panics if the patterns can't be built (see [ScannerBuilder.TryBuild]).
+//
+// NOTE: To scan multiple inputs (possibly concurrently), build a [Lexer] once instead (see [ScannerBuilder.BuildLexer]).
 func (builder *ScannerBuilder[S, V]) Build(defaultValue, finalValue V) *Scanner[S, V] {
+	return builder.BuildLexer(defaultValue, finalValue).NewScanner()
+}
+
+// TryBuild finalizes the construction, converting all added patterns into a fully functional and optimized [Scanner].
+// The value to return when NO pattern matches is defaultValue.
+// The value to return when the input is exhausted on finalValue.
+// Returns an error wrapping [ErrStateLimit] if the patterns require more states than the limit allows.
+// Returns an error wrapping [ErrTooManyClasses] if the patterns use too many distinct classes.
+// Returns an error wrapping [ErrMisplacedAnchor] if a pattern has an anchor that can never hold.
+func (builder *ScannerBuilder[S, V]) TryBuild(defaultValue, finalValue V) (*Scanner[S, V], error) {
+	lexer, err := builder.TryBuildLexer(defaultValue, finalValue)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return lexer.NewScanner(), nil
+}
+
+// BuildLexer finalizes the construction, converting all added patterns into an immutable [Lexer], which creates a
+// [Scanner] per input.
+// The value to return when NO pattern matches is defaultValue.
+// The value to return when the input is exhausted on finalValue.
+// Panics if the patterns can't be built (see [ScannerBuilder.TryBuildLexer]).
+func (builder *ScannerBuilder[S, V]) BuildLexer(defaultValue, finalValue V) *Lexer[S, V] {
+	lexer, err := builder.TryBuildLexer(defaultValue, finalValue)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return lexer
+}
+
+// TryBuildLexer finalizes the construction, converting all added patterns into an immutable [Lexer], which creates a
+// [Scanner] per input.
+// The value to return when NO pattern matches is defaultValue.
+// The value to return when the input is exhausted on finalValue.
+// Returns an error wrapping [ErrStateLimit] if the patterns require more states than the limit allows.
+// Returns an error wrapping [ErrTooManyClasses] if the patterns use too many distinct classes.
+// Returns an error wrapping [ErrMisplacedAnchor] if a pattern has an anchor that can never hold.
+func (builder *ScannerBuilder[S, V]) TryBuildLexer(defaultValue, finalValue V) (*Lexer[S, V], error) {
 	machine := nfa.New[S, V]()
+	machine.SetStateLimit(builder.stateLimit)
 	sState := machine.Start()
 
-	for _, pattern := range builder.patterns {
-		pEndState := pattern.fragment.Build(machine, sState)
+	var rules []rule[S, V]
+	var actions, matchers bool
 
-		machine.AddAcceptingEpsilonTransition(pEndState, pattern.value)
+	order := make([]int, len(builder.patterns)) // The indexes of the patterns, ordered by priority.
+
+	for idx := range order {
+		order[idx] = idx
 	}
 
-	return &Scanner[S, V]{
-		machine:    dfa.FromNfa(machine),
-		illegal:    defaultValue,
-		eof:        finalValue,
-		currentPos: pos.New(),
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(builder.patterns[b].priority, builder.patterns[a].priority)
+	})
+
+	for _, idx := range order {
+		pattern := builder.patterns[idx]
+
+		if pattern.keywords != nil {
+			for kwIdx, aState := range pattern.keywords.build(machine, sState) {
+				rules = addRule(rules, aState.AcceptIdx(), rule[S, V]{value: pattern.keywords.values[kwIdx]})
+			}
+		} else {
+			if !anchorsHold(pattern.fragment, true, true) {
+				return nil, fmt.Errorf("%w: pattern %d", ErrMisplacedAnchor, idx)
+			}
+
+			pEndState := pattern.fragment.Build(machine, sState)
+			aState := machine.AddAcceptingEpsilonTransition(pEndState, pattern.value)
+
+			rules = addRule(rules, aState.AcceptIdx(), rule[S, V]{
+				value:   pattern.value,
+				matcher: pattern.matcher,
+				action:  pattern.action,
+			})
+
+			actions = actions || pattern.action != nil
+			matchers = matchers || pattern.matcher != nil
+
+			if pattern.shortest {
+				machine.MarkShortest(aState)
+			}
+		}
+
+		if machine.Err() != nil {
+			return nil, fmt.Errorf("%w: pattern %d requires more than %d nfa states", ErrStateLimit, idx, builder.stateLimit)
+		}
 	}
+
+	dMachine, err := dfa.FromNfaWithLimit(machine, builder.stateLimit)
+
+	if errors.Is(err, dfa.ErrTooManyClasses) {
+		return nil, fmt.Errorf("%w: %w", ErrTooManyClasses, err)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: the patterns require more than %d dfa states", ErrStateLimit, builder.stateLimit)
+	}
+
+	return &Lexer[S, V]{
+		machine:       dMachine,
+		rules:         rules,
+		illegal:       defaultValue,
+		eof:           finalValue,
+		actions:       actions,
+		matchers:      matchers,
+		startAnchored: dMachine.StartAt(nfa.StartAnchors) != dMachine.Start(),
+		terminators:   builder.terminators,
+		stateLimit:    builder.stateLimit,
+	}, nil
+}
+
+// Returns rules with r stored at index acceptIdx (the acceptance index of the accepting [nfa.State] of r).
+func addRule[S comparable, V any](rules []rule[S, V], acceptIdx int, r rule[S, V]) []rule[S, V] {
+	for len(rules) <= acceptIdx {
+		rules = append(rules, rule[S, V]{})
+	}
+
+	rules[acceptIdx] = r
+
+	return rules
 }
@@ -0,0 +1,132 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a mechanism for transforming patterns into a set of tokens.
+package scanner
+
+import (
+	"unicode"
+
+	"github.com/kdeconinck/align/internal/pkg/automata/nfa"
+)
+
+// A [Fragment] that matches a fixed sequence of runes, ignoring case.
+type fragLiteralFold[V any] struct {
+	symbols []rune
+}
+
+// LiteralFold creates a [Fragment] that matches the exact, ordered sequence of symbols, ignoring case.
+// Two runes are considered equal if they're equivalent under Unicode simple case folding (e.g., 'k', 'K' and the Kelvin
+// sign 'K' are equal).
+// Panics if no symbols are provided.
+func LiteralFold[V any](symbols ...rune) Fragment[rune, V] {
+	if len(symbols) == 0 {
+		panic("LiteralFold: symbols must have elements")
+	}
+
+	return fragLiteralFold[V]{
+		symbols: symbols,
+	}
+}
+
+// Build creates a chain of nfa states where each state is connected to the next one by every case variant of the
+// literal's symbol.
+func (frag fragLiteralFold[V]) Build(machine *nfa.Nfa[rune, V], startState *nfa.State[rune, V]) *nfa.State[rune, V] {
+	last := startState
+
+	for _, sym := range frag.symbols {
+		next := machine.NewState()
+
+		for f := sym; ; {
+			machine.Connect(last, f, next)
+
+			if f = unicode.SimpleFold(f); f == sym {
+				break
+			}
+		}
+
+		last = next
+	}
+
+	return last
+}
+
+// Folder is the interface for a [Fragment] that's NOT created by this package and that can be made case-insensitive
+// (see [Fold]).
+type Folder[V any] interface {
+	// Fold returns a case-insensitive version of the fragment.
+	Fold() Fragment[rune, V]
+}
+
+// Fold returns a case-insensitive version of fragment. Every rune matched by fragment (or any of its sub fragments)
+// also matches all runes that are equivalent under Unicode simple case folding.
+// A [Fragment] that's NOT created by this package is made case-insensitive by its [Folder] implementation.
+// Panics if fragment (or any of its sub fragments) is NOT created by this package and does NOT implement [Folder].
+func Fold[V any](fragment Fragment[rune, V]) Fragment[rune, V] {
+	switch frag := fragment.(type) {
+	case fragLiteral[rune, V]:
+		return LiteralFold[V](frag.symbols...)
+
+	case fragLiteralFold[V]:
+		return frag
+
+	case fragSequence[rune, V]:
+		return fragSequence[rune, V]{fragments: foldAll(frag.fragments)}
+
+	case fragAnyOf[rune, V]:
+		return fragAnyOf[rune, V]{fragments: foldAll(frag.fragments)}
+
+	case fragRepeat[rune, V]:
+		frag.fragment = Fold(frag.fragment)
+
+		return frag
+
+	case fragAnchor[rune, V]:
+		return frag
+
+	case fragClass[V]:
+		class := *frag.class
+		class.fold = true
+
+		return fragClass[V]{class: &class}
+
+	case Folder[V]:
+		return frag.Fold()
+
+	default:
+		panic("Fold: unsupported fragment")
+	}
+}
+
+// Returns a case-insensitive version of each fragment in fragments.
+func foldAll[V any](fragments []Fragment[rune, V]) []Fragment[rune, V] {
+	out := make([]Fragment[rune, V], len(fragments))
+
+	for idx, frag := range fragments {
+		out[idx] = Fold(frag)
+	}
+
+	return out
+}
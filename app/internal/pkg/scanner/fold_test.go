@@ -0,0 +1,235 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "scanner" package.
+package scanner_test
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/automata/nfa"
+	"github.com/kdeconinck/align/internal/pkg/scanner"
+)
+
+// UT: Use a 'LiteralFold' without symbols.
+func TestLiteralFoldPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	handler := func() {
+		scanner.LiteralFold[string]()
+	}
+
+	// Act / assert.
+	assert.Panicf(t, handler, "\n\n"+
+		"UT Name:  Using a 'LiteralFold' fragment without symbols causes a panic.\n"+
+		"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+		"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+}
+
+// UT: Use 'Fold' with a fragment that's NOT created by the "scanner" package and that doesn't implement 'Folder'.
+func TestFoldPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	handler := func() {
+		scanner.Fold[string](customFragment{})
+	}
+
+	// Act / assert.
+	assert.Panicf(t, handler, "\n\n"+
+		"UT Name:  Using 'Fold' with a fragment that doesn't implement 'Folder' causes a panic.\n"+
+		"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+		"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+}
+
+// UT: Build a [scanner.Scanner] using case-insensitive fragments and tokenize a given input.
+func TestScanner_Fold(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("Scanning a 'LiteralFold' fragment produces the value, regardless of the spelling.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.LiteralFold[string]([]rune("select")...), "KW_SELECT").
+			Add(scanner.Literal[rune, string](' '), "WS").
+			Build("ILLEGAL", "EOF")
+
+		rRdr := newRuneReader(strings.NewReader("select SELECT SeLeCt"))
+
+		// Act.
+		got, want := readN(s, rRdr, 6), newSlice("KW_SELECT", "WS", "KW_SELECT", "WS", "KW_SELECT", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning a 'LiteralFold' fragment produces the value, regardless of the spelling.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning a 'LiteralFold' fragment uses Unicode simple case folding.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.LiteralFold[string]('k', 'ß'), "KSS").
+			Build("ILLEGAL", "EOF")
+
+		rRdr := newRuneReader(strings.NewReader("KßkẞKß"))
+
+		// Act.
+		got, want := readN(s, rRdr, 4), newSlice("KSS", "KSS", "KSS", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning a 'LiteralFold' fragment uses Unicode simple case folding.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning a folded fragment uses the 'Folder' implementation of a custom fragment.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		frag := scanner.Fold(scanner.Sequence(scanner.Literal[rune, string]('<'), foldableFragment{}))
+
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(frag, "TAG_X").
+			Build("ILLEGAL", "EOF")
+
+		rRdr := newRuneReader(strings.NewReader("<x<X"))
+
+		// Act.
+		got, want := readN(s, rRdr, 3), newSlice("TAG_X", "TAG_X", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning a folded fragment uses the 'Folder' implementation of a custom fragment.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning a folded fragment with an anchor only matches where the anchor holds.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		frag := scanner.Fold(scanner.Sequence(scanner.LineStart[rune, string](), scanner.Literal[rune, string]('x')))
+
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(frag, "X").
+			Add(scanner.Literal[rune, string]('\n'), "NL").
+			Build("ILLEGAL", "EOF")
+
+		rRdr := newRuneReader(strings.NewReader("X\nxx"))
+
+		// Act.
+		got, want := readN(s, rRdr, 5), newSlice("X", "NL", "X", "ILLEGAL", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning a folded fragment with an anchor only matches where the anchor holds.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning a folded fragment produces the value, regardless of the spelling.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		frag := scanner.Fold(scanner.Sequence(
+			scanner.Literal[rune, string]('<', 'd', 'i', 'v'),
+			scanner.RepeatAtLeast(0, scanner.AnyOf(
+				scanner.Literal[rune, string](' '),
+				scanner.InTable[string](unicode.Lower),
+			)),
+			scanner.Literal[rune, string]('>'),
+		))
+
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(frag, "TAG_DIV").
+			Build("ILLEGAL", "EOF")
+
+		rRdr := newRuneReader(strings.NewReader("<div><DIV HIDDEN><Div x>"))
+
+		// Act.
+		got, want := readN(s, rRdr, 4), newSlice("TAG_DIV", "TAG_DIV", "TAG_DIV", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning a folded fragment produces the value, regardless of the spelling.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning a folded fragment has NO effect on the original fragment.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		frag := scanner.InTable[string](unicode.Lower)
+
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.Fold(frag), "FOLDED").
+			Build("ILLEGAL", "EOF")
+
+		o := scanner.NewScannerBuilder[rune, string]().
+			Add(frag, "ORIGINAL").
+			Build("ILLEGAL", "EOF")
+
+		// Act.
+		got := append(readN(s, newRuneReader(strings.NewReader("A")), 1), readN(o, newRuneReader(strings.NewReader("A")), 1)...)
+		want := newSlice("FOLDED", "ILLEGAL")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning a folded fragment has NO effect on the original fragment.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+}
+
+// A [scanner.Fragment] that's NOT created by the "scanner" package.
+type customFragment struct{}
+
+// Build adds a transition on 'x'.
+func (customFragment) Build(machine *nfa.Nfa[rune, string], startState *nfa.State[rune, string]) *nfa.State[rune, string] {
+	return machine.Add(startState, 'x')
+}
+
+// A [scanner.Fragment] that's NOT created by the "scanner" package, but that implements [scanner.Folder].
+type foldableFragment struct{}
+
+// Build adds a transition on 'x'.
+func (foldableFragment) Build(machine *nfa.Nfa[rune, string], startState *nfa.State[rune, string]) *nfa.State[rune, string] {
+	return machine.Add(startState, 'x')
+}
+
+// Fold returns a fragment that matches 'x' and 'X'.
+func (foldableFragment) Fold() scanner.Fragment[rune, string] {
+	return scanner.LiteralFold[string]('x')
+}
@@ -143,6 +143,11 @@ func RepeatAtLeast[S comparable, V any](min int, fragment Fragment[S, V]) Fragme
 
 // RepeatBetween creates a [Fragment] that matches the given fragment between 'min' and 'max' times, inclusive.
 // Panics if min is negative or max is less than min.
+//
+// NOTE: The repetition is NOT counted: the automaton holds a copy of fragment for each of the max occurences, so its
+// size (and the time to build it) grows linearly with max. The copies count towards the state limit of the
+// [ScannerBuilder] (see [ScannerBuilder.SetStateLimit]), which turns a repetition that's too large into an error
+// instead of exhausting memory.
 func RepeatBetween[S comparable, V any](min, max int, fragment Fragment[S, V]) Fragment[S, V] {
 	if min < 0 {
 		panic("RepeatBetween: min cannot be negative")
@@ -163,12 +168,21 @@ func RepeatBetween[S comparable, V any](min, max int, fragment Fragment[S, V]) F
 // Build implements the repetition:
 // 1. Mandatory `minOccurence` repetitions (a simple sequence).
 // 2. Optional `maxOccurence - minOccurence` repetitions, or Kleene star logic if no max.
+//
+// The optional repetitions are chained directly onto each other (without intermediate states), where the end of each
+// copy can skip to the end of the repetition.
+// Each occurence is a separate copy of the fragment, since the copies can't be shared: the end of each copy decides
+// how many occurences have been matched.
 func (frag fragRepeat[S, V]) Build(machine *nfa.Nfa[S, V], startState *nfa.State[S, V]) *nfa.State[S, V] {
 	currentState := startState
 
 	// Build the sequence that represents the minimal amount of required occurences.
 	for idx := 0; idx < frag.minOccurence; idx++ {
-		currentState = frag.fragment.Build(machine, currentState)
+		currentState = frag.buildCopy(machine, currentState, idx)
+
+		if machine.Err() != nil {
+			return currentState
+		}
 	}
 
 	endState := machine.NewState()
@@ -176,10 +190,8 @@ func (frag fragRepeat[S, V]) Build(machine *nfa.Nfa[S, V], startState *nfa.State
 
 	// If there's NO maximal amount of required of occurences, loop back to the beginning.
 	if !frag.hasMax {
-		machine.ConnectEpsilon(currentState, endState)
-
 		bodyStartState := machine.AddEpsilonTransition(currentState)
-		bodyEndState := frag.fragment.Build(machine, bodyStartState)
+		bodyEndState := frag.buildCopy(machine, bodyStartState, frag.minOccurence)
 
 		machine.ConnectEpsilon(bodyEndState, bodyStartState)
 		machine.ConnectEpsilon(bodyEndState, endState)
@@ -187,18 +199,39 @@ func (frag fragRepeat[S, V]) Build(machine *nfa.Nfa[S, V], startState *nfa.State
 		return endState
 	}
 
-	if frag.maxOccurence-frag.minOccurence == 0 {
-		return endState
+	for idx := frag.minOccurence; idx < frag.maxOccurence; idx++ {
+		currentState = frag.buildCopy(machine, currentState, idx)
+
+		if machine.Err() != nil {
+			return endState
+		}
+
+		machine.ConnectEpsilon(currentState, endState)
 	}
 
-	for idx := 0; idx < frag.maxOccurence-frag.minOccurence; idx++ {
-		optStartState := machine.AddEpsilonTransition(currentState)
-		optEndState := frag.fragment.Build(machine, optStartState)
+	return endState
+}
 
-		machine.ConnectEpsilon(optEndState, endState)
+// Builds the copy with index idx (starting at 0) of the repeated fragment into machine, starting from startState, and
+// returns its final state.
+// The amount of states required by the first copy is used to reserve the states for the remaining copies (see
+// [nfa.Nfa.Reserve]). This stops the construction before it would exceed the state limit of machine.
+func (frag fragRepeat[S, V]) buildCopy(machine *nfa.Nfa[S, V], startState *nfa.State[S, V], idx int) *nfa.State[S, V] {
+	before := machine.Len()
+	endState := frag.fragment.Build(machine, startState)
 
-		currentState = optEndState
+	if idx == 0 {
+		_ = machine.Reserve((machine.Len() - before) * (frag.copies() - 1))
 	}
 
 	return endState
 }
+
+// Returns the total amount of copies of the repeated fragment that are required to build the repetition.
+func (frag fragRepeat[S, V]) copies() int {
+	if frag.hasMax {
+		return frag.maxOccurence
+	}
+
+	return frag.minOccurence + 1
+}
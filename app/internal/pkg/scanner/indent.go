@@ -0,0 +1,243 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a mechanism for transforming patterns into a set of tokens.
+package scanner
+
+import (
+	"slices"
+
+	"github.com/kdeconinck/align/internal/pkg/collections/queue"
+	"github.com/kdeconinck/align/internal/pkg/pos"
+)
+
+// DefaultTabWidth is the amount of columns between tab stops that's used by an [Indenter] when no width is configured.
+const DefaultTabWidth = 8
+
+// IndentConfig describes the tokens that are relevant for an [Indenter].
+type IndentConfig[V comparable] struct {
+	// Indent is the value of the token that's emitted when the indentation increases.
+	Indent V
+
+	// Dedent is the value of the token that's emitted for every level that's closed when the indentation decreases.
+	Dedent V
+
+	// Newlines contains the values of the tokens that terminate a line.
+	Newlines []V
+
+	// Whitespace contains the values of the tokens that form the indentation of a line.
+	// These tokens may only contain spaces and tabs (or other Unicode spaces, when the symbols are runes).
+	Whitespace []V
+
+	// Comments contains the values of the tokens that don't make a line significant.
+	// A line that only contains whitespace and comments doesn't change the indentation.
+	Comments []V
+
+	// Brackets maps the value of each opening bracket onto the value of its closing bracket.
+	// Lines that start inside a pair of brackets are continuation lines and don't change the indentation.
+	Brackets map[V]V
+
+	// TabWidth is the amount of columns between tab stops (0 means DefaultTabWidth).
+	TabWidth int
+}
+
+// Indenter is a token-stream processor on top of a [Scanner], which produces tokens for indentation-sensitive
+// languages (such as Python, YAML or Haskell).
+// It passes the tokens of the [Scanner] through and emits an indent token before the first significant token of a line
+// that's indented further than the previous one, and a dedent token for every level that's closed by a line that's
+// indented less. The levels that are still open are closed before the final token.
+//
+// The indentation is measured in display columns (see [pos.DisplayWidth]), and a tab advances it to the next tab
+// stop. Since the width of a tab is a matter of configuration, the
+// indentation of two lines must compare the same way when a tab counts as a single column; otherwise the indentation
+// is ambiguous, and a [Diagnostic] is reported.
+// A line whose indentation doesn't match any open level is reported as an inconsistent dedent. The line then opens a
+// new level (without an indent token), so that the lines that follow are compared against it.
+type Indenter[S comparable, V comparable] struct {
+	scanner     *Scanner[S, V]
+	rdr         SymbolReader[S]
+	config      IndentConfig[V]
+	closers     []V // The closing brackets that are expected, innermost last.
+	levels      []indentLevel
+	pending     *queue.Queue[Token[S, V]]
+	diagnostics []Diagnostic
+	lineStart   bool         // Indicates whether NO significant token has been read on the current line.
+	measuring   bool         // Indicates whether only whitespace has been read on the current line.
+	indent      indentLevel  // The indentation of the current line.
+	indentText  []rune       // The whitespace that forms the indentation of the current line.
+	indentStart pos.Position // The start of the current line.
+}
+
+// The indentation of a line.
+type indentLevel struct {
+	columns int // The display width, where a tab advances to the next tab stop.
+	symbols int // The amount of symbols, where a tab counts as a single column.
+}
+
+// NewIndenter returns an [Indenter] that processes the tokens that scanner reads from rdr, according to config.
+// Panics if the tab width is negative.
+func NewIndenter[S comparable, V comparable](scanner *Scanner[S, V], rdr SymbolReader[S], config IndentConfig[V]) *Indenter[S, V] {
+	if config.TabWidth < 0 {
+		panic("NewIndenter: tab width cannot be negative")
+	}
+
+	if config.TabWidth == 0 {
+		config.TabWidth = DefaultTabWidth
+	}
+
+	return &Indenter[S, V]{
+		scanner:     scanner,
+		rdr:         rdr,
+		config:      config,
+		levels:      []indentLevel{{}},
+		pending:     queue.New[Token[S, V]](),
+		lineStart:   true,
+		measuring:   true,
+		indentStart: scanner.currentPos,
+	}
+}
+
+// Next returns the next token, including the indent and dedent tokens.
+func (ind *Indenter[S, V]) Next() Token[S, V] {
+	for ind.pending.Len() == 0 {
+		ind.process(ind.scanner.Next(ind.rdr))
+	}
+
+	token, _ := ind.pending.Dequeue()
+
+	return token
+}
+
+// Diagnostics returns a copy of the problems with the indentation that have been detected so far.
+func (ind *Indenter[S, V]) Diagnostics() []Diagnostic {
+	return slices.Clone(ind.diagnostics)
+}
+
+// Queues token, preceded by the indent and dedent tokens that it causes.
+func (ind *Indenter[S, V]) process(token Token[S, V]) {
+	switch {
+	case len(token.Lexeme) == 0: // The input is exhausted.
+		ind.dedentTo(indentLevel{}, token.Span.Start)
+
+	case len(ind.closers) > 0:
+		ind.trackBrackets(token.Value)
+
+	case slices.Contains(ind.config.Newlines, token.Value):
+		ind.lineStart, ind.measuring = true, true
+		ind.indent, ind.indentStart = indentLevel{}, token.Span.End
+		ind.indentText = ind.indentText[:0]
+
+	case !ind.lineStart:
+		ind.trackBrackets(token.Value)
+
+	case slices.Contains(ind.config.Whitespace, token.Value):
+		if ind.measuring {
+			ind.measure(token.Lexeme)
+		}
+
+	case slices.Contains(ind.config.Comments, token.Value):
+		ind.measuring = false
+
+	default:
+		ind.lineStart, ind.measuring = false, false
+		ind.indentLine(pos.Span{Start: ind.indentStart, End: token.Span.Start})
+		ind.trackBrackets(token.Value)
+	}
+
+	ind.pending.Enqueue(token)
+}
+
+// Compares the indentation of the current line (located at span) with the innermost open level and queues the
+// matching indent or dedent tokens.
+func (ind *Indenter[S, V]) indentLine(span pos.Span) {
+	top := ind.levels[len(ind.levels)-1]
+
+	switch {
+	case ind.indent.columns > top.columns:
+		if ind.indent.symbols <= top.symbols {
+			ind.report(span, "inconsistent use of tabs and spaces in indentation")
+		}
+
+		ind.levels = append(ind.levels, ind.indent)
+		ind.pending.Enqueue(Token[S, V]{Value: ind.config.Indent, Span: span})
+
+	case ind.indent.columns < top.columns:
+		ind.dedentTo(ind.indent, span.End)
+
+		if top = ind.levels[len(ind.levels)-1]; top.columns != ind.indent.columns {
+			ind.report(span, "unindent does not match any outer indentation level")
+			ind.levels = append(ind.levels, ind.indent)
+		} else if top.symbols != ind.indent.symbols {
+			ind.report(span, "inconsistent use of tabs and spaces in indentation")
+		}
+
+	case ind.indent.symbols != top.symbols:
+		ind.report(span, "inconsistent use of tabs and spaces in indentation")
+	}
+}
+
+// Closes (and queues a dedent token for) every open level that's indented further than level.
+// The dedent tokens are empty and located at p.
+func (ind *Indenter[S, V]) dedentTo(level indentLevel, p pos.Position) {
+	for len(ind.levels) > 1 && ind.levels[len(ind.levels)-1].columns > level.columns {
+		ind.levels = ind.levels[:len(ind.levels)-1]
+		ind.pending.Enqueue(Token[S, V]{Value: ind.config.Dedent, Span: pos.Span{Start: p, End: p}})
+	}
+}
+
+// Updates the brackets that are open with value.
+// A closing bracket that doesn't match the innermost open bracket is ignored.
+func (ind *Indenter[S, V]) trackBrackets(value V) {
+	if closer, ok := ind.config.Brackets[value]; ok {
+		ind.closers = append(ind.closers, closer)
+	} else if len(ind.closers) > 0 && ind.closers[len(ind.closers)-1] == value {
+		ind.closers = ind.closers[:len(ind.closers)-1]
+	}
+}
+
+// Advances the indentation of the current line over the symbols of a whitespace token.
+// The columns of the indentation are its display width (see [pos.DisplayWidth]), so a wide space (such as U+3000)
+// occupies 2 columns. A symbol that isn't a rune or a byte occupies 1 column.
+func (ind *Indenter[S, V]) measure(symbols []S) {
+	for _, sym := range symbols {
+		r, ok := runeOf(sym)
+
+		if !ok {
+			r = ' '
+		}
+
+		ind.indentText = append(ind.indentText, r)
+	}
+
+	ind.indent = indentLevel{
+		columns: pos.DisplayWidth(string(ind.indentText), ind.config.TabWidth),
+		symbols: len(ind.indentText),
+	}
+}
+
+// Records a problem with the indentation, located at span.
+func (ind *Indenter[S, V]) report(span pos.Span, message string) {
+	ind.diagnostics = append(ind.diagnostics, Diagnostic{Span: span, Message: message})
+}
@@ -0,0 +1,273 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "scanner" package.
+package scanner_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/scanner"
+)
+
+// UT: Create an [scanner.Indenter] with an invalid configuration.
+func TestNewIndenterPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	handler := func() {
+		scanner.NewIndenter(newIndentScanner(), newSliceReader([]rune("")), scanner.IndentConfig[string]{TabWidth: -1})
+	}
+
+	// Act / assert.
+	assert.Panicf(t, handler, "\n\n"+
+		"UT Name:  Creating an 'Indenter' with a negative tab width causes a panic.\n"+
+		"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+		"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+}
+
+// UT: Read the tokens of a given input through an [scanner.Indenter].
+func TestIndenter(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		input           string
+		tabWidth        int
+		want            []string
+		wantDiagnostics []string
+	}{
+		"Reading lines with the same indentation produces NO indent or dedent tokens.": {
+			input: "a\nb\n",
+			want:  newSlice("IDENT", "NL", "IDENT", "NL", "EOF"),
+		},
+		"Reading an indented block produces an indent and a dedent token.": {
+			input: "if a:\n    b\n    c\nd\n",
+			want: newSlice(
+				"IF", "IDENT", "COLON", "NL",
+				"INDENT", "IDENT", "NL",
+				"IDENT", "NL",
+				"DEDENT", "IDENT", "NL", "EOF",
+			),
+		},
+		"Reading nested blocks that end at the same line produces a dedent token per block.": {
+			input: "if a:\n  if b:\n    c\nd",
+			want: newSlice(
+				"IF", "IDENT", "COLON", "NL",
+				"INDENT", "IF", "IDENT", "COLON", "NL",
+				"INDENT", "IDENT", "NL",
+				"DEDENT", "DEDENT", "IDENT", "EOF",
+			),
+		},
+		"Reading the end of the input closes all the open blocks.": {
+			input: "if a:\n  if b:\n    c\n",
+			want: newSlice(
+				"IF", "IDENT", "COLON", "NL",
+				"INDENT", "IF", "IDENT", "COLON", "NL",
+				"INDENT", "IDENT", "NL",
+				"DEDENT", "DEDENT", "EOF",
+			),
+		},
+		"Reading blank lines and lines with only a comment doesn't change the indentation.": {
+			input: "if a:\n  b\n\n# note\n      \n  c\n",
+			want: newSlice(
+				"IF", "IDENT", "COLON", "NL",
+				"INDENT", "IDENT", "NL",
+				"NL",
+				"COMMENT", "NL",
+				"NL",
+				"IDENT", "NL",
+				"DEDENT", "EOF",
+			),
+		},
+		"Reading continuation lines inside brackets doesn't change the indentation.": {
+			input: "f(a,\n      b,\n  (c,\nd))\ne\n",
+			want: newSlice(
+				"IDENT", "LPAREN", "IDENT", "COMMA", "NL",
+				"IDENT", "COMMA", "NL",
+				"LPAREN", "IDENT", "COMMA", "NL",
+				"IDENT", "RPAREN", "RPAREN", "NL",
+				"IDENT", "NL", "EOF",
+			),
+		},
+		"Reading a tab followed by spaces, after a line indented by the tab, produces an indent token.": {
+			input:    "if a:\n\tb\n\t  c\n",
+			tabWidth: 4,
+			want: newSlice(
+				"IF", "IDENT", "COLON", "NL",
+				"INDENT", "IDENT", "NL",
+				"INDENT", "IDENT", "NL",
+				"DEDENT", "DEDENT", "EOF",
+			),
+		},
+		"Reading an indentation with a wide space measures its display width.": {
+			input: "a\n\u3000b\n c\n",
+			want: newSlice(
+				"IDENT", "NL",
+				"INDENT", "IDENT", "NL",
+				"DEDENT", "IDENT", "NL",
+				"DEDENT", "EOF",
+			),
+			wantDiagnostics: newSlice("3:1-3:2: unindent does not match any outer indentation level"),
+		},
+		"Reading a tab and spaces that depend on the tab width produces a diagnostic.": {
+			input: "if a:\n\tb\n        c\n",
+			want: newSlice(
+				"IF", "IDENT", "COLON", "NL",
+				"INDENT", "IDENT", "NL",
+				"IDENT", "NL",
+				"DEDENT", "EOF",
+			),
+			wantDiagnostics: newSlice("3:1-3:9: inconsistent use of tabs and spaces in indentation"),
+		},
+		"Reading an indentation that's wider only because of the tab width produces a diagnostic.": {
+			input: "if a:\n    b\n\tc\n",
+			want: newSlice(
+				"IF", "IDENT", "COLON", "NL",
+				"INDENT", "IDENT", "NL",
+				"INDENT", "IDENT", "NL",
+				"DEDENT", "DEDENT", "EOF",
+			),
+			wantDiagnostics: newSlice("3:1-3:2: inconsistent use of tabs and spaces in indentation"),
+		},
+		"Reading a dedent that doesn't match an outer level produces a diagnostic.": {
+			input: "if a:\n    b\n  c\n  d\ne\n",
+			want: newSlice(
+				"IF", "IDENT", "COLON", "NL",
+				"INDENT", "IDENT", "NL",
+				"DEDENT", "IDENT", "NL",
+				"IDENT", "NL",
+				"DEDENT", "IDENT", "NL", "EOF",
+			),
+			wantDiagnostics: newSlice("3:1-3:3: unindent does not match any outer indentation level"),
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			ind := scanner.NewIndenter(newIndentScanner(), newSliceReader([]rune(tc.input)), scanner.IndentConfig[string]{
+				Indent:     "INDENT",
+				Dedent:     "DEDENT",
+				Newlines:   newSlice("NL"),
+				Whitespace: newSlice("WS"),
+				Comments:   newSlice("COMMENT"),
+				Brackets:   map[string]string{"LPAREN": "RPAREN"},
+				TabWidth:   tc.tabWidth,
+			})
+
+			// Act.
+			got := readIndented(ind)
+			gotDiagnostics := formatDiagnostics(ind.Diagnostics())
+
+			// Assert.
+			assert.EqualSf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, tc.want, got)
+
+			assert.EqualSf(t, gotDiagnostics, tc.wantDiagnostics, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected (diagnostics): %v.\033[0m\n"+
+				"\033[31mActual (diagnostics):   %v.\033[0m\n\n", tcName, tc.wantDiagnostics, gotDiagnostics)
+		})
+	}
+}
+
+// UT: Modify the diagnostics returned by a [scanner.Indenter].
+func TestIndenter_Diagnostics(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	ind := scanner.NewIndenter(newIndentScanner(), newSliceReader([]rune("if a:\n    b\n  c\n")), scanner.IndentConfig[string]{
+		Indent:     "INDENT",
+		Dedent:     "DEDENT",
+		Newlines:   newSlice("NL"),
+		Whitespace: newSlice("WS"),
+	})
+
+	readIndented(ind)
+
+	// Act.
+	ind.Diagnostics()[0].Message = "modified"
+	got, want := formatDiagnostics(ind.Diagnostics()), newSlice("3:1-3:3: unindent does not match any outer indentation level")
+
+	// Assert.
+	assert.EqualSf(t, got, want, "\n\n"+
+		"UT Name:  Modifying the returned diagnostics doesn't modify the diagnostics of the indenter.\n"+
+		"\033[32mExpected: %v.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", want, got)
+}
+
+// Returns a [scanner.Scanner] for a tiny, indentation-sensitive language.
+func newIndentScanner() *scanner.Scanner[rune, string] {
+	return scanner.NewScannerBuilder[rune, string]().
+		Add(scanner.Literal[rune, string]('i', 'f'), "IF").
+		Add(scanner.ASCIIIdentifier[string](), "IDENT").
+		Add(scanner.Literal[rune, string](':'), "COLON").
+		Add(scanner.Literal[rune, string](','), "COMMA").
+		Add(scanner.Literal[rune, string]('('), "LPAREN").
+		Add(scanner.Literal[rune, string](')'), "RPAREN").
+		Add(scanner.Sequence(
+			scanner.Literal[rune, string]('#'),
+			scanner.RepeatAtLeast(0, scanner.AnyOf(scanner.Category[string]("L"), scanner.Literal[rune, string](' '))),
+		), "COMMENT").
+		Add(scanner.RepeatAtLeast(1, scanner.AnyOf(
+			scanner.Literal[rune, string](' '),
+			scanner.Literal[rune, string]('\t'),
+			scanner.Literal[rune, string]('\u3000'),
+		)), "WS").
+		Add(scanner.Literal[rune, string]('\n'), "NL").
+		Build("ILLEGAL", "EOF")
+}
+
+// Read the values of the tokens of ind (except whitespace) until the final value is produced.
+func readIndented[S comparable](ind *scanner.Indenter[S, string]) []string {
+	var values []string
+
+	for token := ind.Next(); ; token = ind.Next() {
+		if token.Value != "WS" {
+			values = append(values, token.Value)
+		}
+
+		if token.Value == "EOF" {
+			return values
+		}
+	}
+}
+
+// Utility: Return the human-readable representation ("start-end: message") of diagnostics.
+func formatDiagnostics(diagnostics []scanner.Diagnostic) []string {
+	var formatted []string
+
+	for _, d := range diagnostics {
+		formatted = append(formatted, fmt.Sprintf("%s-%s: %s", d.Span.Start, d.Span.End, d.Message))
+	}
+
+	return formatted
+}
@@ -0,0 +1,175 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a mechanism for transforming patterns into a set of tokens.
+package scanner
+
+import "iter"
+
+// All returns an iterator over the tokens that are read from rdr, starting at the current position.
+// The iterator stops before the token with the final value. When rdr returns an error (other than [io.EOF]) or the
+// automaton exceeds its state limit (see [Lexer.Err]), the error is yielded (with an empty token) and the iterator
+// stops.
+func (s *Scanner[S, V]) All(rdr SymbolReader[S]) iter.Seq2[Token[S, V], error] {
+	return func(yield func(Token[S, V], error) bool) {
+		for {
+			token := s.Next(rdr)
+
+			if err := s.reader.err; err != nil {
+				yield(Token[S, V]{}, err)
+
+				return
+			}
+
+			if err := s.Err(); err != nil {
+				yield(Token[S, V]{}, err)
+
+				return
+			}
+
+			if len(token.Lexeme) == 0 || !yield(token, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Tokens returns an iterator over the tokens of input, starting at the current position.
+// The iterator stops before the token with the final value.
+func (s *Scanner[S, V]) Tokens(input []S) iter.Seq[Token[S, V]] {
+	return func(yield func(Token[S, V]) bool) {
+		for token := range s.All(NewSliceReader(input)) {
+			if !yield(token) {
+				return
+			}
+		}
+	}
+}
+
+// Filter returns an iterator over the tokens of seq for which keep returns true.
+// Errors are always yielded.
+func Filter[S comparable, V any](seq iter.Seq2[Token[S, V], error], keep func(Token[S, V]) bool) iter.Seq2[Token[S, V], error] {
+	return func(yield func(Token[S, V], error) bool) {
+		for token, err := range seq {
+			if err != nil || keep(token) {
+				if !yield(token, err) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Collect returns the tokens of seq.
+// Returns the tokens collected so far and the error if seq yields an error.
+func Collect[S comparable, V any](seq iter.Seq2[Token[S, V], error]) ([]Token[S, V], error) {
+	var tokens []Token[S, V]
+
+	for token, err := range seq {
+		if err != nil {
+			return tokens, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+// Peekable reads the tokens of a sequence, while allowing to look ahead at a limited amount of tokens.
+// A Peekable must be stopped (see [Peekable.Stop]) when it's no longer used before the sequence is exhausted.
+type Peekable[S comparable, V any] struct {
+	next      func() (Token[S, V], error, bool)
+	stop      func()
+	lookahead int
+	buffer    []peeked[S, V] // The tokens that are peeked, but not yet read.
+}
+
+// A token (or an error) that's peeked.
+type peeked[S comparable, V any] struct {
+	token Token[S, V]
+	err   error
+}
+
+// NewPeekable returns a [Peekable] that reads the tokens of seq and that can look ahead at most lookahead tokens.
+// Panics if lookahead is less than 1.
+func NewPeekable[S comparable, V any](seq iter.Seq2[Token[S, V], error], lookahead int) *Peekable[S, V] {
+	if lookahead < 1 {
+		panic("NewPeekable: lookahead must be at least 1")
+	}
+
+	next, stop := iter.Pull2(seq)
+
+	return &Peekable[S, V]{next: next, stop: stop, lookahead: lookahead, buffer: make([]peeked[S, V], 0, lookahead)}
+}
+
+// Peek returns the token (or the error) at distance n from the next token, without reading it. Peek(0) returns the
+// next token. The last return value is false if the sequence doesn't contain that many tokens.
+// Panics if n is negative or if it's NOT less than the lookahead of p.
+func (p *Peekable[S, V]) Peek(n int) (Token[S, V], error, bool) {
+	if n < 0 || n >= p.lookahead {
+		panic("Peek: distance is out of range")
+	}
+
+	for len(p.buffer) <= n {
+		token, err, ok := p.next()
+
+		if !ok {
+			return Token[S, V]{}, nil, false
+		}
+
+		p.buffer = append(p.buffer, peeked[S, V]{token: token, err: err})
+	}
+
+	return p.buffer[n].token, p.buffer[n].err, true
+}
+
+// Next reads and returns the next token (or error). The last return value is false if the sequence is exhausted.
+func (p *Peekable[S, V]) Next() (Token[S, V], error, bool) {
+	if len(p.buffer) == 0 {
+		return p.next()
+	}
+
+	next := p.buffer[0]
+	p.buffer = append(p.buffer[:0], p.buffer[1:]...)
+
+	return next.token, next.err, true
+}
+
+// All returns an iterator over the tokens (and errors) that aren't read yet.
+func (p *Peekable[S, V]) All() iter.Seq2[Token[S, V], error] {
+	return func(yield func(Token[S, V], error) bool) {
+		for token, err, ok := p.Next(); ok; token, err, ok = p.Next() {
+			if !yield(token, err) {
+				return
+			}
+		}
+	}
+}
+
+// Stop stops reading the sequence.
+func (p *Peekable[S, V]) Stop() {
+	p.stop()
+}
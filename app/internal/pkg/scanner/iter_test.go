@@ -0,0 +1,269 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "scanner" package.
+package scanner_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/scanner"
+)
+
+// The error returned by a [failingReader].
+var errRead = errors.New("read failed")
+
+// UT: Iterate over the tokens of a [scanner.Scanner].
+func TestScanner_All(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("Iterating over the tokens of a reader produces all tokens, except the final one.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := newPushTestScanner()
+
+		// Act.
+		tokens, err := scanner.Collect(s.All(newSliceReader([]rune("ab 11"))))
+		got := formatTokens(tokens)
+		want := newSlice("IDENT(ab)@1:1-1:3", "WS( )@1:3-1:4", "NUMBER(11)@1:4-1:6")
+
+		// Assert.
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  Iterating over the tokens of a reader produces all tokens, except the final one.\n"+
+			"\033[32mExpected: <nil>.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", err)
+
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Iterating over the tokens of a reader produces all tokens, except the final one.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Iterating over the tokens of a reader that fails produces the error.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := newPushTestScanner()
+		rdr := &failingReader{rdr: newSliceReader([]rune("ab 11")), remaining: 4}
+
+		// Act.
+		tokens, err := scanner.Collect(s.All(rdr))
+		got := formatTokens(tokens)
+		want := newSlice("IDENT(ab)@1:1-1:3", "WS( )@1:3-1:4")
+
+		// Assert.
+		assert.Errorf(t, err, errRead, "\n\n"+
+			"UT Name:  Iterating over the tokens of a reader that fails produces the error.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", errRead, err)
+
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Iterating over the tokens of a reader that fails produces the error.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Iterating over the tokens of a slice produces all tokens, except the final one.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := newPushTestScanner()
+
+		// Act.
+		var got []string
+
+		for token := range s.Tokens([]rune("a.b")) {
+			got = append(got, formatTokens(newSlice(token))...)
+		}
+
+		want := newSlice("IDENT(a)@1:1-1:2", "DOT(.)@1:2-1:3", "IDENT(b)@1:3-1:4")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Iterating over the tokens of a slice produces all tokens, except the final one.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Stopping the iteration early doesn't read the remaining tokens.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := newPushTestScanner()
+		rdr := newSliceReader([]rune("a b c"))
+
+		// Act.
+		for range s.All(rdr) {
+			break
+		}
+
+		got, want := s.NextToken(rdr), "WS"
+
+		// Assert.
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  Stopping the iteration early doesn't read the remaining tokens.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+}
+
+// UT: Filter the tokens of a sequence.
+func TestFilter(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	s := newPushTestScanner()
+	rdr := &failingReader{rdr: newSliceReader([]rune("a b 1 ")), remaining: 6}
+
+	// Act.
+	tokens, err := scanner.Collect(scanner.Filter(s.All(rdr), func(token scanner.Token[rune, string]) bool {
+		return token.Value != "WS"
+	}))
+
+	got := formatTokens(tokens)
+	want := newSlice("IDENT(a)@1:1-1:2", "IDENT(b)@1:3-1:4", "NUMBER(1)@1:5-1:6")
+
+	// Assert.
+	assert.EqualSf(t, got, want, "\n\n"+
+		"UT Name:  Filtering the tokens of a sequence produces the tokens that are kept.\n"+
+		"\033[32mExpected: %v.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", want, got)
+
+	assert.Errorf(t, err, errRead, "\n\n"+
+		"UT Name:  Filtering the tokens of a sequence produces the errors.\n"+
+		"\033[32mExpected: %v.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", errRead, err)
+}
+
+// UT: Create a [scanner.Peekable] with an invalid lookahead.
+func TestNewPeekablePanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	handler := func() {
+		scanner.NewPeekable(newPushTestScanner().All(newSliceReader([]rune("a"))), 0)
+	}
+
+	// Act / assert.
+	assert.Panicf(t, handler, "\n\n"+
+		"UT Name:  Creating a 'Peekable' without lookahead causes a panic.\n"+
+		"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+		"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+}
+
+// UT: Look ahead at the tokens of a sequence.
+func TestPeekable(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("Peeking beyond the lookahead causes a panic.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		p := scanner.NewPeekable(newPushTestScanner().All(newSliceReader([]rune("a b c"))), 2)
+		defer p.Stop()
+
+		handler := func() {
+			p.Peek(2)
+		}
+
+		// Act / assert.
+		assert.Panicf(t, handler, "\n\n"+
+			"UT Name:  Peeking beyond the lookahead causes a panic.\n"+
+			"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+			"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+	})
+
+	t.Run("Peeking and reading tokens produces them in order.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		p := scanner.NewPeekable(newPushTestScanner().All(newSliceReader([]rune("a b"))), 3)
+		defer p.Stop()
+
+		// Act.
+		var got []string
+
+		record := func(operation string, token scanner.Token[rune, string], _ error, ok bool) {
+			got = append(got, fmt.Sprintf("%s=%s/%t", operation, string(token.Lexeme), ok))
+		}
+
+		token, err, ok := p.Peek(2)
+		record("peek(2)", token, err, ok)
+		token, err, ok = p.Peek(0)
+		record("peek(0)", token, err, ok)
+		token, err, ok = p.Next()
+		record("next", token, err, ok)
+		token, err, ok = p.Peek(2)
+		record("peek(2)", token, err, ok)
+		token, err, ok = p.Peek(1)
+		record("peek(1)", token, err, ok)
+
+		for token, err := range p.All() {
+			record("all", token, err, true)
+		}
+
+		token, err, ok = p.Next()
+		record("next", token, err, ok)
+
+		want := newSlice(
+			"peek(2)=b/true", "peek(0)=a/true", "next=a/true", "peek(2)=/false", "peek(1)=b/true", "all= /true",
+			"all=b/true", "next=/false",
+		)
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Peeking and reading tokens produces them in order.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+}
+
+// A [scanner.SymbolReader] that fails after reading a given amount of symbols.
+type failingReader struct {
+	rdr       scanner.SymbolReader[rune]
+	remaining int
+}
+
+// ReadSymbol reads the next symbol, or fails if there are NO remaining symbols.
+func (fRdr *failingReader) ReadSymbol() (rune, error) {
+	if fRdr.remaining == 0 {
+		return 0, errRead
+	}
+
+	fRdr.remaining--
+
+	return fRdr.rdr.ReadSymbol()
+}
+
+// UnreadSymbol unreads the last symbol read.
+func (fRdr *failingReader) UnreadSymbol() error {
+	fRdr.remaining++
+
+	return fRdr.rdr.UnreadSymbol()
+}
@@ -0,0 +1,123 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a mechanism for transforming patterns into a set of tokens.
+package scanner
+
+import (
+	"sort"
+
+	"github.com/kdeconinck/align/internal/pkg/automata/nfa"
+)
+
+// A set of keywords (and their values) that's built into an [nfa.Nfa] as a trie.
+type keywordTrie[S comparable, V any] struct {
+	keywords [][]S
+	values   []V
+}
+
+// Returns a new [keywordTrie] containing keywords.
+// The keywords are sorted to ensure that the constructed automaton is deterministic.
+// Panics if a keyword is empty or if S isn't byte or rune.
+func newKeywordTrie[S comparable, V any](keywords map[string]V) *keywordTrie[S, V] {
+	names := make([]string, 0, len(keywords))
+
+	for name := range keywords {
+		if name == "" {
+			panic("AddKeywords: keywords cannot be empty")
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	trie := &keywordTrie[S, V]{
+		keywords: make([][]S, 0, len(names)),
+		values:   make([]V, 0, len(names)),
+	}
+
+	for _, name := range names {
+		trie.keywords = append(trie.keywords, symbolsOf[S](name))
+		trie.values = append(trie.values, keywords[name])
+	}
+
+	return trie
+}
+
+// Builds the trie into machine, starting from startState, and returns the accepting state of each keyword.
+// The trie is entered through a single epsilon transition, and keywords that share a prefix share the states of that
+// prefix. This keeps the sets of states that are constructed when converting the machine into a deterministic
+// automaton small.
+//
+// NOTE: Since the keywords are sorted, a keyword is always added before any keyword it's a prefix of. As a result, the
+// state for the last symbol of a keyword is always new, and it can be created as an accepting state.
+func (trie *keywordTrie[S, V]) build(machine *nfa.Nfa[S, V], startState *nfa.State[S, V]) []*nfa.State[S, V] {
+	type edge struct {
+		from int
+		sym  S
+	}
+
+	root := machine.AddEpsilonTransition(startState)
+	children := make(map[edge]*nfa.State[S, V])
+	accepting := make([]*nfa.State[S, V], len(trie.keywords))
+
+	for idx, keyword := range trie.keywords {
+		node := root
+		last := len(keyword) - 1
+
+		for _, sym := range keyword[:last] {
+			key := edge{from: node.ID(), sym: sym}
+			child, ok := children[key]
+
+			if !ok {
+				child = machine.Add(node, sym)
+				children[key] = child
+			}
+
+			node = child
+		}
+
+		accepting[idx] = machine.AddAccepting(node, keyword[last], trie.values[idx])
+		children[edge{from: node.ID(), sym: keyword[last]}] = accepting[idx]
+	}
+
+	return accepting
+}
+
+// Returns the symbols of s.
+// Panics if S isn't byte or rune.
+func symbolsOf[S comparable](s string) []S {
+	switch any([]S(nil)).(type) {
+	case []rune:
+		return any([]rune(s)).([]S)
+
+	case []byte:
+		return any([]byte(s)).([]S)
+
+	default:
+		panic("scanner: symbols must be of type byte or rune")
+	}
+}
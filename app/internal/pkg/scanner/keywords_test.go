@@ -0,0 +1,215 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "scanner" package.
+package scanner_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/scanner"
+)
+
+// UT: Use 'AddKeywords' with invalid keywords.
+func TestAddKeywordsPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("Using 'AddKeywords' with an empty keyword causes a panic.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		handler := func() {
+			scanner.NewScannerBuilder[rune, string]().AddKeywords(map[string]string{"": "EMPTY"})
+		}
+
+		// Act / assert.
+		assert.Panicf(t, handler, "\n\n"+
+			"UT Name:  Using 'AddKeywords' with an empty keyword causes a panic.\n"+
+			"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+			"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+	})
+
+	t.Run("Using 'AddKeywords' with symbols that aren't bytes or runes causes a panic.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		handler := func() {
+			scanner.NewScannerBuilder[string, string]().AddKeywords(map[string]string{"if": "KW_IF"})
+		}
+
+		// Act / assert.
+		assert.Panicf(t, handler, "\n\n"+
+			"UT Name:  Using 'AddKeywords' with symbols that aren't bytes or runes causes a panic.\n"+
+			"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+			"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+	})
+}
+
+// UT: Build a [scanner.Scanner] using keywords and tokenize a given input.
+func TestScanner_Keywords(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("Scanning keywords with a shared prefix produces the value of each keyword.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := scanner.NewScannerBuilder[rune, string]().
+			AddKeywords(map[string]string{
+				"in":        "KW_IN",
+				"int":       "KW_INT",
+				"interface": "KW_INTERFACE",
+				"if":        "KW_IF",
+			}).
+			Add(scanner.Literal[rune, string](' '), "WS").
+			Build("ILLEGAL", "EOF")
+
+		rRdr := newSliceReader([]rune("if in int interface inter"))
+
+		// Act.
+		got := readN(s, rRdr, 12)
+		want := newSlice("KW_IF", "WS", "KW_IN", "WS", "KW_INT", "WS", "KW_INTERFACE", "WS", "KW_INT", "ILLEGAL", "ILLEGAL", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning keywords with a shared prefix produces the value of each keyword.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning keywords respects the order in which they were added.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := scanner.NewScannerBuilder[rune, string]().
+			AddKeywords(map[string]string{"if": "KW_IF", "for": "KW_FOR"}).
+			Add(scanner.ASCIIIdentifier[string](), "IDENT").
+			Add(scanner.Literal[rune, string](' '), "WS").
+			Build("ILLEGAL", "EOF")
+
+		rRdr := newRuneReader(strings.NewReader("for fort if"))
+
+		// Act.
+		got, want := readN(s, rRdr, 6), newSlice("KW_FOR", "WS", "IDENT", "WS", "KW_IF", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning keywords respects the order in which they were added.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning keywords with byte symbols produces the value of each keyword.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := scanner.NewScannerBuilder[byte, string]().
+			AddKeywords(map[string]string{"SELECT": "KW_SELECT", "SET": "KW_SET"}).
+			Build("ILLEGAL", "EOF")
+
+		rdr := newSliceReader([]byte("SETSELECT"))
+
+		// Act.
+		got, want := readN(s, rdr, 3), newSlice("KW_SET", "KW_SELECT", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning keywords with byte symbols produces the value of each keyword.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+}
+
+// Benchmark(s): Build a [scanner.Scanner] where each keyword is added as a separate 'Literal' fragment.
+func BenchmarkBuildKeywordsLiteral_10(b *testing.B)   { benchmarkBuildKeywordsLiteral(10, b) }
+func BenchmarkBuildKeywordsLiteral_100(b *testing.B)  { benchmarkBuildKeywordsLiteral(100, b) }
+func BenchmarkBuildKeywordsLiteral_300(b *testing.B)  { benchmarkBuildKeywordsLiteral(300, b) }
+func BenchmarkBuildKeywordsLiteral_1000(b *testing.B) { benchmarkBuildKeywordsLiteral(1000, b) }
+
+// Benchmark(s): Build a [scanner.Scanner] where all keywords are added as a single trie.
+func BenchmarkBuildKeywordsTrie_10(b *testing.B)   { benchmarkBuildKeywordsTrie(10, b) }
+func BenchmarkBuildKeywordsTrie_100(b *testing.B)  { benchmarkBuildKeywordsTrie(100, b) }
+func BenchmarkBuildKeywordsTrie_300(b *testing.B)  { benchmarkBuildKeywordsTrie(300, b) }
+func BenchmarkBuildKeywordsTrie_1000(b *testing.B) { benchmarkBuildKeywordsTrie(1000, b) }
+
+// Benchmark: Measure the performance of building a [scanner.Scanner] with a 'Literal' fragment per keyword.
+// Parameters:
+// - count: The amount of keywords.
+// - b:     The [testing.B] instance.
+func benchmarkBuildKeywordsLiteral(count int, b *testing.B) {
+	keywords := generateKeywords(count)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		builder := scanner.NewScannerBuilder[rune, string]()
+
+		for keyword, value := range keywords {
+			builder.Add(scanner.Literal[rune, string]([]rune(keyword)...), value)
+		}
+
+		builder.Build("ILLEGAL", "EOF")
+	}
+}
+
+// Benchmark: Measure the performance of building a [scanner.Scanner] with all keywords in a single trie.
+// Parameters:
+// - count: The amount of keywords.
+// - b:     The [testing.B] instance.
+func benchmarkBuildKeywordsTrie(count int, b *testing.B) {
+	keywords := generateKeywords(count)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		scanner.NewScannerBuilder[rune, string]().
+			AddKeywords(keywords).
+			Build("ILLEGAL", "EOF")
+	}
+}
+
+// Returns count distinct keywords (mapped to themselves) with a length between 2 and 10.
+// The keywords are generated from a small alphabet, so that many of them share a prefix, like in real languages.
+func generateKeywords(count int) map[string]string {
+	keywords := make(map[string]string, count)
+	seed := uint32(1)
+
+	for len(keywords) < count {
+		var sb strings.Builder
+
+		seed = seed*1664525 + 1013904223
+		length := 2 + int(seed>>28)%9
+
+		for range length {
+			seed = seed*1664525 + 1013904223
+			sb.WriteByte("aeilnorst"[seed>>29])
+		}
+
+		keywords[sb.String()] = sb.String()
+	}
+
+	return keywords
+}
@@ -0,0 +1,113 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a mechanism for transforming patterns into a set of tokens.
+package scanner
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/kdeconinck/align/internal/pkg/automata/dfa"
+	"github.com/kdeconinck/align/internal/pkg/pos"
+)
+
+// Lexer is the compiled form of the patterns of a [ScannerBuilder].
+// A Lexer is immutable and safe for concurrent use: it's built once, and each input is scanned by its own (cheap)
+// [Scanner] that's created by the Lexer.
+//
+// NOTE: The matchers of the patterns (if any) are shared by all scanners, and must be safe for concurrent use when
+// the scanners are used concurrently.
+type Lexer[S comparable, V any] struct {
+	machine       *dfa.Dfa[S, V]
+	rules         []rule[S, V]    // The rules of the patterns, indexed by the acceptance index of their end state.
+	illegal       V               // The value to return for an unmatchable sequence.
+	eof           V               // The value to return when the input is fully consumed.
+	actions       bool            // Indicates whether any of the rules has an action.
+	matchers      bool            // Indicates whether any of the rules has a matcher.
+	startAnchored bool            // Indicates whether any of the rules starts with a start anchor (see [LineStart]).
+	terminators   pos.Terminators // The line terminators that decide where a line ends.
+	stateLimit    int             // The maximum amount of states of the automaton (see [ScannerBuilder.SetStateLimit]).
+	pool          sync.Pool       // The scanners that are released, and can be acquired again.
+}
+
+// NewScanner returns a new [Scanner] that's positioned at the start of an input.
+func (lexer *Lexer[S, V]) NewScanner() *Scanner[S, V] {
+	return &Scanner[S, V]{lexer: lexer, currentPos: pos.New()}
+}
+
+// Acquire returns a [Scanner] that's positioned at the start of an input. The scanner is reused from the ones that
+// were released (see [Lexer.Release]) if possible, which avoids allocating its buffers again.
+func (lexer *Lexer[S, V]) Acquire() *Scanner[S, V] {
+	if s, ok := lexer.pool.Get().(*Scanner[S, V]); ok {
+		return s
+	}
+
+	return lexer.NewScanner()
+}
+
+// Err returns an error wrapping [ErrStateLimit] if the states that the automaton of the lexer constructs while
+// scanning (for the combinations of classes, such as [InTable] and [Category]) exceeded the state limit (see
+// [ScannerBuilder.SetStateLimit]), or nil otherwise.
+// From then on, a token that requires a new state ends before the symbol that requires it, so the tokens that are
+// scanned afterwards (by any of the lexer's scanners) may differ from the ones of a lexer without a limit.
+func (lexer *Lexer[S, V]) Err() error {
+	if err := lexer.machine.Err(); err != nil {
+		return fmt.Errorf("%w: the input requires more than %d dfa states", ErrStateLimit, lexer.stateLimit)
+	}
+
+	return nil
+}
+
+// Release resets s (see [Scanner.Reset]) and stores it, so that it can be acquired again (see [Lexer.Acquire]).
+// The scanner must NOT be used after it's released.
+// Panics if s isn't created by the lexer.
+func (lexer *Lexer[S, V]) Release(s *Scanner[S, V]) {
+	if s.lexer != lexer {
+		panic("Release: scanner isn't created by the lexer")
+	}
+
+	s.Reset()
+	lexer.pool.Put(s)
+}
+
+// Lexer returns the [Lexer] that created s.
+func (s *Scanner[S, V]) Lexer() *Lexer[S, V] {
+	return s.lexer
+}
+
+// Err returns the error of the [Lexer] that created s (see [Lexer.Err]).
+func (s *Scanner[S, V]) Err() error {
+	return s.lexer.Err()
+}
+
+// Reset positions s at the start of a (new) input, keeping its buffers.
+func (s *Scanner[S, V]) Reset() {
+	s.reader = tokenReader[S]{symbols: s.reader.symbols[:0]}
+	s.currentPos = pos.New()
+	s.offset = 0
+	s.prev = 0
+	s.diagnostics = nil
+}
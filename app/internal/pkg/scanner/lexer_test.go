@@ -0,0 +1,161 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "scanner" package.
+package scanner_test
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+)
+
+// UT: Release a [scanner.Scanner] to a [scanner.Lexer] that didn't create it.
+func TestLexer_ReleasePanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	lexer := newPushTestScanner().Lexer()
+
+	handler := func() {
+		lexer.Release(newPushTestScanner())
+	}
+
+	// Act / assert.
+	assert.Panicf(t, handler, "\n\n"+
+		"UT Name:  Releasing a scanner that isn't created by the lexer causes a panic.\n"+
+		"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+		"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+}
+
+// UT: Scan multiple inputs with the scanners of a [scanner.Lexer].
+func TestLexer(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("Resetting a scanner scans a new input from the start.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := newPushTestScanner()
+		readAllTokens(s, newSliceReader([]rune("ab\ncd")))
+
+		// Act.
+		s.Reset()
+		got := formatTokens(readAllTokens(s, newSliceReader([]rune("x.y"))))
+		want := newSlice("IDENT(x)@1:1-1:2", "DOT(.)@1:2-1:3", "IDENT(y)@1:3-1:4", "EOF()@1:4-1:4")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Resetting a scanner scans a new input from the start.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Acquiring a released scanner scans a new input from the start.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		lexer := newPushTestScanner().Lexer()
+		s := lexer.Acquire()
+		readAllTokens(s, newSliceReader([]rune("ab\ncd")))
+		lexer.Release(s)
+
+		// Act.
+		s = lexer.Acquire()
+		got := formatTokens(readAllTokens(s, newSliceReader([]rune("1 a"))))
+		want := newSlice("NUMBER(1)@1:1-1:2", "WS( )@1:2-1:3", "IDENT(a)@1:3-1:4", "EOF()@1:4-1:4")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Acquiring a released scanner scans a new input from the start.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning inputs concurrently produces the same tokens as scanning them sequentially.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		rnd := rand.New(rand.NewSource(36))
+		alphabet := []rune("ab1. */\n?")
+		inputs := make([][]rune, 64)
+
+		for idx := range inputs {
+			inputs[idx] = make([]rune, rnd.Intn(200))
+
+			for sIdx := range inputs[idx] {
+				inputs[idx][sIdx] = alphabet[rnd.Intn(len(alphabet))]
+			}
+		}
+
+		lexer := newPushTestScanner().Lexer()
+		got := make([][]string, len(inputs))
+
+		// Act.
+		var wg sync.WaitGroup
+
+		for idx, input := range inputs {
+			wg.Go(func() {
+				s := lexer.Acquire()
+				defer lexer.Release(s)
+
+				got[idx] = formatTokensWithLookahead(readAllTokens(s, newSliceReader(input)))
+			})
+		}
+
+		wg.Wait()
+
+		// Assert.
+		for idx, input := range inputs {
+			want := formatTokensWithLookahead(readAllTokens(newPushTestScanner(), newSliceReader(input)))
+
+			assert.EqualSf(t, got[idx], want, "\n\n"+
+				"UT Name:  Scanning %q concurrently produces the same tokens as scanning it sequentially.\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", string(input), want, got[idx])
+		}
+	})
+}
+
+// Benchmark: Scan many small inputs with scanners that are acquired from a [scanner.Lexer].
+func BenchmarkLexer_Acquire(b *testing.B) {
+	lexer := newPushTestScanner().Lexer()
+	input := generateSource(1 << 10)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(input)))
+
+	for b.Loop() {
+		s := lexer.Acquire()
+		rdr := newSliceReader(input)
+
+		for s.NextToken(rdr) != "EOF" {
+		}
+
+		lexer.Release(s)
+	}
+}
@@ -0,0 +1,92 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a mechanism for transforming patterns into a set of tokens.
+package scanner
+
+import "github.com/kdeconinck/align/internal/pkg/pos"
+
+// Mark is a checkpoint of a [Scanner], which allows to scan the input again from that point (see [Scanner.Rewind]).
+// Since the scanner unreads the symbols it looked ahead at before returning a token, a mark doesn't need to hold any
+// pending lookahead.
+type Mark struct {
+	offset   int          // The amount of symbols consumed from the start of the input.
+	position pos.Position // The current position in the source.
+	prev     rune         // The last symbol consumed (as a rune).
+	reported int          // The amount of diagnostics reported (see [Scanner.Diagnostics]).
+}
+
+// Offset returns the amount of symbols consumed from the start of the input at the mark.
+func (m Mark) Offset() int {
+	return m.offset
+}
+
+// Position returns the position in the source at the mark.
+func (m Mark) Position() pos.Position {
+	return m.position
+}
+
+// Mark returns a checkpoint of the current state of s.
+func (s *Scanner[S, V]) Mark() Mark {
+	return Mark{offset: s.offset, position: s.currentPos, prev: s.prev, reported: len(s.diagnostics)}
+}
+
+// Rewind restores the state of s (and rdr) to mark, so that the tokens after mark are scanned again.
+// The diagnostics reported since mark are dropped, since they're reported again when the tokens are scanned again.
+// The symbols consumed since mark are unread from rdr at once if it implements [MultiUnreader] (e.g., [SliceReader]),
+// or one by one otherwise. Returns the error of rdr if it can't unread them (the state of s is unchanged).
+// Panics if mark is ahead of s.
+//
+// NOTE: Unlike [Scanner.Reset], which starts a new input, Rewind takes rdr, since the symbols consumed since mark must
+// be unread from it.
+func (s *Scanner[S, V]) Rewind(rdr SymbolReader[S], mark Mark) error {
+	if mark.offset > s.offset {
+		panic("Rewind: mark is ahead of the scanner")
+	}
+
+	if err := unreadSymbols(rdr, s.offset-mark.offset); err != nil {
+		return err
+	}
+
+	s.offset, s.currentPos, s.prev = mark.offset, mark.position, mark.prev
+	s.diagnostics = s.diagnostics[:min(mark.reported, len(s.diagnostics))]
+
+	return nil
+}
+
+// Unreads the last n symbols from rdr.
+func unreadSymbols[S comparable](rdr SymbolReader[S], n int) error {
+	if mRdr, ok := rdr.(MultiUnreader); ok {
+		return mRdr.UnreadSymbols(n)
+	}
+
+	for range n {
+		if err := rdr.UnreadSymbol(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "scanner" package.
+package scanner_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/scanner"
+)
+
+// UT: Rewind a [scanner.Scanner] to a mark that's ahead of it.
+func TestScanner_RewindPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	s := newPushTestScanner()
+	rdr := scanner.NewSliceReader([]rune("ab cd"))
+	start := s.Mark()
+	s.Next(rdr)
+	ahead := s.Mark()
+	_ = s.Rewind(rdr, start)
+
+	handler := func() {
+		_ = s.Rewind(rdr, ahead)
+	}
+
+	// Act / assert.
+	assert.Panicf(t, handler, "\n\n"+
+		"UT Name:  Rewinding to a mark that's ahead of the scanner causes a panic.\n"+
+		"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+		"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+}
+
+// UT: Rewind a [scanner.Scanner] to a mark.
+func TestScanner_Rewind(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		rdr scanner.SymbolReader[rune]
+	}{
+		"Rewinding with a reader that unreads multiple symbols at once scans the tokens after the mark again.": {
+			rdr: scanner.NewSliceReader([]rune("ab\ncd 1.e")),
+		},
+		"Rewinding with a reader that unreads one symbol at a time scans the tokens after the mark again.": {
+			rdr: newSliceReader([]rune("ab\ncd 1.e")),
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			s := newPushTestScanner()
+			readTokensN(s, tc.rdr, 2)
+			mark := s.Mark()
+			readTokensN(s, tc.rdr, 4)
+
+			// Act.
+			err := s.Rewind(tc.rdr, mark)
+			got := formatTokens(readAllTokens(s, tc.rdr))
+			want := newSlice(
+				"IDENT(cd)@2:1-2:3", "WS( )@2:3-2:4", "NUMBER(1)@2:4-2:5", "DOT(.)@2:5-2:6", "IDENT(e)@2:6-2:7",
+				"EOF()@2:7-2:7",
+			)
+
+			// Assert.
+			assert.Nilf(t, err, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: <nil>.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, err)
+
+			assert.EqualSf(t, got, want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, want, got)
+		})
+	}
+
+	t.Run("Rewinding with a reader that can't unread the symbols keeps the state of the scanner.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := newPushTestScanner()
+		rdr := newRuneReader(strings.NewReader("ab cd"))
+		mark := s.Mark()
+		readTokensN(s, rdr, 2)
+
+		// Act.
+		err := s.Rewind(rdr, mark)
+		got, want := s.Mark().Offset(), 3
+
+		// Assert.
+		assert.NotNilf(t, err, "\n\n"+
+			"UT Name:  Rewinding with a reader that can't unread the symbols fails.\n"+
+			"\033[32mExpected: NOT <nil>.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", err)
+
+		assert.Equalf(t, got, want, "\n\n"+
+			"UT Name:  Rewinding with a reader that can't unread the symbols keeps the state of the scanner.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+}
+
+// UT: Rewind a [scanner.Scanner] over a token whose action reports a diagnostic.
+func TestScanner_RewindDiagnostics(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	s := newActionScanner()
+	rdr := scanner.NewSliceReader([]rune("x 300 y"))
+	readTokensN(s, rdr, 1)
+	mark := s.Mark()
+	readTokensN(s, rdr, 4)
+
+	// Act.
+	err := s.Rewind(rdr, mark)
+	readAllTokens(s, rdr)
+	got, want := formatDiagnostics(s.Diagnostics()), newSlice("1:3-1:6: number out of range")
+
+	// Assert.
+	assert.Nilf(t, err, "\n\n"+
+		"UT Name:  Rewinding over a token whose action reports a diagnostic succeeds.\n"+
+		"\033[32mExpected: <nil>.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", err)
+
+	assert.EqualSf(t, got, want, "\n\n"+
+		"UT Name:  Rewinding over a token whose action reports a diagnostic reports it only once.\n"+
+		"\033[32mExpected: %v.\033[0m\n"+
+		"\033[31mActual:   %v.\033[0m\n\n", want, got)
+}
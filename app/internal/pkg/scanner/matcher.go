@@ -0,0 +1,99 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a mechanism for transforming patterns into a set of tokens.
+package scanner
+
+import "errors"
+
+// Matcher completes a token whose prefix has been matched by the [Fragment] of a pattern. It's used for tokens that
+// can't be expressed as a regular pattern, such as nested block comments, raw strings with a variable amount of
+// delimiters or heredocs with a user-chosen terminator.
+//
+// A Matcher receives the symbols of the prefix (which are only valid during the call) and reads the remainder of the
+// token from rdr, which is positioned right after the prefix. It returns the amount of symbols (after the prefix) that
+// are part of the token and whether the token is valid. Any symbols that are read beyond the reported length are
+// unread by the [Scanner]. The reported length can't exceed the amount of symbols that are read.
+//
+// A Matcher participates in the maximal munch rule of the [Scanner] with the total length of the token (prefix and
+// remainder). When it fails, the [Scanner] falls back to the longest match of the other patterns.
+type Matcher[S comparable] func(prefix []S, rdr SymbolReader[S]) (length int, ok bool)
+
+// ErrUnreadPrefix is the error returned when a [Matcher] tries to unread a symbol of the prefix of its token.
+var ErrUnreadPrefix = errors.New("scanner: cannot unread the prefix of the token")
+
+// WithMatcher returns a [PatternOption] which hands control to matcher as soon as the [Fragment] of the pattern has
+// matched the prefix of a token.
+func WithMatcher[S comparable, V any](matcher Matcher[S]) PatternOption[S, V] {
+	return func(p *pattern[S, V]) {
+		p.matcher = matcher
+	}
+}
+
+// A [SymbolReader] that tracks the amount of symbols that are read from the wrapped [SymbolReader].
+type countingReader[S comparable] struct {
+	rdr   SymbolReader[S]
+	count int
+}
+
+// ReadSymbol reads the next symbol from the wrapped reader.
+func (cRdr *countingReader[S]) ReadSymbol() (S, error) {
+	sym, err := cRdr.rdr.ReadSymbol()
+
+	if err == nil {
+		cRdr.count++
+	}
+
+	return sym, err
+}
+
+// UnreadSymbol unreads the last symbol read from the wrapped reader.
+// Returns [ErrUnreadPrefix] if every symbol read by this reader has already been unread.
+func (cRdr *countingReader[S]) UnreadSymbol() error {
+	if cRdr.count == 0 {
+		return ErrUnreadPrefix
+	}
+
+	err := cRdr.rdr.UnreadSymbol()
+
+	if err == nil {
+		cRdr.count--
+	}
+
+	return err
+}
+
+// Runs matcher (for the given prefix) on rdr and returns the amount of symbols it added to the token (or -1 if it
+// failed). The symbols read by the matcher are NOT unread.
+func runMatcher[S comparable](matcher Matcher[S], prefix []S, rdr SymbolReader[S]) int {
+	cRdr := &countingReader[S]{rdr: rdr}
+	length, ok := matcher(prefix, cRdr)
+
+	if !ok || length < 0 || length > cRdr.count {
+		return -1
+	}
+
+	return length
+}
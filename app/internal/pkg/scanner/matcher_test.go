@@ -0,0 +1,323 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "scanner" package.
+package scanner_test
+
+import (
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/scanner"
+)
+
+// UT: Build a [scanner.Scanner] using patterns with a [scanner.Matcher] and tokenize a given input.
+func TestScanner_Matcher(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("Scanning nested block comments produces the value.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.Literal[rune, string]('/', '*'), "COMMENT", scanner.WithMatcher[rune, string](nestedComment)).
+			Add(scanner.Literal[rune, string]('/'), "DIV").
+			Add(scanner.Literal[rune, string]('*'), "MUL").
+			Add(scanner.ASCIIIdentifier[string](), "IDENT").
+			Build("ILLEGAL", "EOF")
+
+		rdr := newSliceReader([]rune("/* a /* b */ c */x/*y"))
+
+		// Act.
+		got, want := readN(s, rdr, 6), newSlice("COMMENT", "IDENT", "DIV", "MUL", "IDENT", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning nested block comments produces the value.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning raw strings uses the prefix of the token.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		prefix := scanner.Sequence(
+			scanner.Literal[rune, string]('r'),
+			scanner.RepeatAtLeast(0, scanner.Literal[rune, string]('#')),
+			scanner.Literal[rune, string]('"'),
+		)
+
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(prefix, "RAW_STRING", scanner.WithMatcher[rune, string](rawString)).
+			Add(scanner.ASCIIIdentifier[string](), "IDENT").
+			Add(scanner.Literal[rune, string]('#'), "HASH").
+			Build("ILLEGAL", "EOF")
+
+		rdr := newSliceReader([]rune(`r#"a"b"#r"c"raw##`))
+
+		// Act.
+		got, want := readN(s, rdr, 6), newSlice("RAW_STRING", "RAW_STRING", "IDENT", "HASH", "HASH", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning raw strings uses the prefix of the token.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning heredocs uses the terminator in the prefix of the token.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		prefix := scanner.Sequence(
+			scanner.Literal[rune, string]('<', '<'),
+			scanner.ASCIIIdentifier[string](),
+			scanner.Literal[rune, string]('\n'),
+		)
+
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(prefix, "HEREDOC", scanner.WithMatcher[rune, string](heredoc)).
+			Add(scanner.ASCIIIdentifier[string](), "IDENT").
+			Add(scanner.Literal[rune, string]('\n'), "NEWLINE").
+			Build("ILLEGAL", "EOF")
+
+		rdr := newSliceReader([]rune("<<END\nline 1\nEND x\nEND\nx"))
+
+		// Act.
+		got, want := readN(s, rdr, 4), newSlice("HEREDOC", "NEWLINE", "IDENT", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning heredocs uses the terminator in the prefix of the token.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning a token whose matcher fails falls back to the longest match of the other patterns.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		prefix := scanner.Sequence(
+			scanner.Literal[rune, string]('['),
+			scanner.RepeatAtLeast(0, scanner.Literal[rune, string]('=')),
+			scanner.Literal[rune, string]('['),
+		)
+
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(prefix, "LONG_STRING", scanner.WithMatcher[rune, string](longBracket)).
+			Add(scanner.Literal[rune, string]('[', '['), "DOUBLE_LBRACKET").
+			Add(scanner.Literal[rune, string]('['), "LBRACKET").
+			Add(scanner.Literal[rune, string]('='), "ASSIGN").
+			Add(scanner.ASCIIIdentifier[string](), "IDENT").
+			Build("ILLEGAL", "EOF")
+
+		rdr := newSliceReader([]rune("[==[a]]b]==][[x[=[y"))
+
+		// Act.
+		got := readN(s, rdr, 9)
+		want := newSlice("LONG_STRING", "DOUBLE_LBRACKET", "IDENT", "LBRACKET", "ASSIGN", "LBRACKET", "IDENT", "EOF", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning a token whose matcher fails falls back to the longest match of the other patterns.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning a token completed by a matcher respects the maximal munch rule.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange: The matcher never adds symbols, so the longer identifier wins.
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.Literal[rune, string]('a'), "A", scanner.WithMatcher[rune, string](emptyMatcher)).
+			Add(scanner.Literal[rune, string]('a', 'b'), "AB").
+			Build("ILLEGAL", "EOF")
+
+		rdr := newSliceReader([]rune("aba"))
+
+		// Act.
+		got, want := readN(s, rdr, 3), newSlice("AB", "A", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning a token completed by a matcher respects the maximal munch rule.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning a token completed by a matcher of a lower priority pattern extends the token.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.Literal[rune, string]('['), "LBRACKET").
+			Add(scanner.Literal[rune, string]('['), "LONG_STRING", scanner.WithMatcher[rune, string](closeBrackets)).
+			Build("ILLEGAL", "EOF")
+
+		rdr := newSliceReader([]rune("[x]]"))
+
+		// Act.
+		got, want := formatTokens(readAllTokens(s, rdr)), newSlice("LONG_STRING([x]])@1:1-1:5", "EOF()@1:5-1:5")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning a token completed by a matcher of a lower priority pattern extends the token.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning a token of equal length prefers the pattern without a matcher with the highest priority.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.Literal[rune, string]('['), "LBRACKET").
+			Add(scanner.Literal[rune, string]('['), "LONG_STRING", scanner.WithMatcher[rune, string](emptyMatcher)).
+			Build("ILLEGAL", "EOF")
+
+		rdr := newSliceReader([]rune("[["))
+
+		// Act.
+		got, want := readN(s, rdr, 3), newSlice("LBRACKET", "LBRACKET", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning a token of equal length prefers the pattern without a matcher with the highest priority.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("A matcher can NOT unread the prefix of its token.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		var got error
+
+		matcher := func(_ []rune, rdr scanner.SymbolReader[rune]) (int, bool) {
+			got = rdr.UnreadSymbol()
+
+			return 0, true
+		}
+
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.Literal[rune, string]('a'), "A", scanner.WithMatcher[rune, string](matcher)).
+			Build("ILLEGAL", "EOF")
+
+		// Act.
+		s.NextToken(newSliceReader([]rune("a")))
+
+		// Assert.
+		assert.Errorf(t, got, scanner.ErrUnreadPrefix, "\n\n"+
+			"UT Name:  A matcher can NOT unread the prefix of its token.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", scanner.ErrUnreadPrefix, got)
+	})
+}
+
+// A [scanner.Matcher] for the remainder of a nested block comment (after "/*").
+func nestedComment(_ []rune, rdr scanner.SymbolReader[rune]) (int, bool) {
+	depth, length := 1, 0
+
+	var prev rune
+
+	for depth > 0 {
+		r, err := rdr.ReadSymbol()
+
+		if err != nil {
+			return 0, false
+		}
+
+		length++
+
+		switch {
+		case prev == '/' && r == '*':
+			depth++
+			r = 0
+
+		case prev == '*' && r == '/':
+			depth--
+			r = 0
+		}
+
+		prev = r
+	}
+
+	return length, true
+}
+
+// A [scanner.Matcher] for the remainder of a Rust raw string (after `r#"`), terminated by '"' and the same amount of
+// '#' symbols as in the prefix.
+func rawString(prefix []rune, rdr scanner.SymbolReader[rune]) (int, bool) {
+	return readUntil(rdr, append([]rune{'"'}, prefix[1:len(prefix)-1]...))
+}
+
+// A [scanner.Matcher] for the remainder of a heredoc (after "<<NAME\n"), terminated by a line containing "NAME".
+func heredoc(prefix []rune, rdr scanner.SymbolReader[rune]) (int, bool) {
+	terminator := append([]rune{'\n'}, prefix[2:len(prefix)-1]...)
+	terminator = append(terminator, '\n')
+
+	length, ok := readUntil(rdr, terminator)
+
+	// The final newline isn't part of the token.
+	return length - 1, ok
+}
+
+// A [scanner.Matcher] for the remainder of a Lua long string (after "[==["), terminated by "]==]".
+func longBracket(prefix []rune, rdr scanner.SymbolReader[rune]) (int, bool) {
+	terminator := []rune(string(prefix))
+	terminator[0], terminator[len(terminator)-1] = ']', ']'
+
+	return readUntil(rdr, terminator)
+}
+
+// A [scanner.Matcher] that always succeeds without adding any symbols to the token.
+func emptyMatcher(_ []rune, _ scanner.SymbolReader[rune]) (int, bool) {
+	return 0, true
+}
+
+// A [scanner.Matcher] for the remainder of a token that's terminated by "]]".
+func closeBrackets(_ []rune, rdr scanner.SymbolReader[rune]) (int, bool) {
+	return readUntil(rdr, []rune("]]"))
+}
+
+// Reads from rdr until terminator has been read and returns the amount of symbols read.
+func readUntil(rdr scanner.SymbolReader[rune], terminator []rune) (int, bool) {
+	var read []rune
+
+	for {
+		r, err := rdr.ReadSymbol()
+
+		if err != nil {
+			return 0, false
+		}
+
+		read = append(read, r)
+
+		if len(read) >= len(terminator) && string(read[len(read)-len(terminator):]) == string(terminator) {
+			return len(read), true
+		}
+	}
+}
@@ -0,0 +1,300 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a mechanism for transforming patterns into a set of tokens.
+package scanner
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/kdeconinck/align/internal/pkg/automata/nfa"
+	"github.com/kdeconinck/align/internal/pkg/pos"
+)
+
+// The maximum amount of symbols that's searched for a line break after the point where the input is split.
+const maxBoundarySearch = 4096
+
+// ScanParallel returns the tokens of input (including the token with the final value), starting at the current
+// position. The tokens are identical to the ones that are produced by [Scanner.Next].
+// The input is split into the given amount of chunks, which are scanned concurrently with the same automaton. Each
+// chunk is scanned speculatively, under the assumption that a token starts where the chunk starts (preferably right
+// after a line break). Where that assumption turns out to be wrong, the tokens are scanned again until they line up
+// with the tokens of the chunk. The lexemes of the tokens share the memory of input.
+// The current position of the scanner is moved to the end of the input.
+// Panics if chunks is less than 1.
+//
+// NOTE: The matchers of the patterns (if any) are called concurrently. The actions of the patterns (if any) are run
+// sequentially, once the tokens are located.
+func (s *Scanner[S, V]) ScanParallel(input []S, chunks int) []Token[S, V] {
+	if chunks < 1 {
+		panic("ScanParallel: amount of chunks must be at least 1")
+	}
+
+	bounds := splitBounds(input, chunks)
+	scanned := make([][]tokenRecord[S, V], len(bounds)-1)
+
+	var wg sync.WaitGroup
+
+	for idx := range scanned {
+		wg.Go(func() {
+			scanned[idx] = s.clone().scanRecords(input, bounds[idx], bounds[idx+1])
+		})
+	}
+
+	wg.Wait()
+
+	segments := s.stitch(input, scanned)
+	tokens := s.locate(input, segments)
+	s.currentPos, s.prev = tokens[len(tokens)-1].Span.End, s.runeBefore(input, len(input))
+	s.offset += len(input)
+
+	if s.lexer.actions {
+		tokens = s.runActions(tokens, segments)
+	}
+
+	return tokens
+}
+
+// A token, identified by its offset in the input.
+type tokenRecord[S comparable, V any] struct {
+	value     V
+	start     int
+	end       int
+	lookahead int
+	action    Action[S, V] // The action of the rule that matched the token (if any).
+}
+
+// Returns a [Scanner] that shares the [Lexer] of s, but that has its own state.
+func (s *Scanner[S, V]) clone() *Scanner[S, V] {
+	return &Scanner[S, V]{lexer: s.lexer, currentPos: s.currentPos, prev: s.prev}
+}
+
+// Returns the records of the tokens of input, starting with a token at offset start, until a token ends at or after
+// offset end.
+func (s *Scanner[S, V]) scanRecords(input []S, start, end int) []tokenRecord[S, V] {
+	rdr := &SliceReader[S]{input: input, offset: start}
+	records := make([]tokenRecord[S, V], 0, (end-start)/3+1)
+
+	for rdr.offset < end {
+		records = append(records, s.scanRecord(rdr))
+	}
+
+	return records
+}
+
+// Returns the record of the token at the offset of rdr.
+func (s *Scanner[S, V]) scanRecord(rdr *SliceReader[S]) tokenRecord[S, V] {
+	start := rdr.offset
+	value, length, action := s.scan(rdr, s.anchorsAtOffset(rdr.input, start))
+
+	return tokenRecord[S, V]{
+		value:     value,
+		start:     start,
+		end:       start + length,
+		lookahead: len(s.reader.symbols) - length,
+		action:    action,
+	}
+}
+
+// Returns the start anchors that hold at offset in input, given that input starts at the current position.
+// The symbols before offset that don't occupy a column are skipped, until a symbol that ends a line is found.
+func (s *Scanner[S, V]) anchorsAtOffset(input []S, offset int) nfa.Anchor {
+	for ; offset > 0; offset-- {
+		p := pos.Position{Line: 1, Column: 2}
+		advance(&p, s.lexer.terminators, s.runeBefore(input, offset-1), input[offset-1])
+
+		switch {
+		case p.Line > 1:
+			return nfa.AnchorLineStart
+
+		case p.Column > 2:
+			return 0
+		}
+	}
+
+	return s.startAnchors()
+}
+
+// Returns the symbol before offset in input (as a rune), given that input starts at the current position.
+func (s *Scanner[S, V]) runeBefore(input []S, offset int) rune {
+	if offset == 0 {
+		return s.prev
+	}
+
+	r, _ := runeOf(input[offset-1])
+
+	return r
+}
+
+// Returns the records of all the tokens of input (in consecutive segments), given the records that are scanned
+// speculatively per chunk.
+// The records of a chunk are used from the first record that starts where a token of the preceding chunks ends.
+// Until then, the tokens are scanned again.
+func (s *Scanner[S, V]) stitch(input []S, scanned [][]tokenRecord[S, V]) [][]tokenRecord[S, V] {
+	var segments [][]tokenRecord[S, V]
+	var rescanned []tokenRecord[S, V]
+	rdr := &SliceReader[S]{input: input}
+
+	for _, chunk := range scanned {
+		for len(chunk) > 0 {
+			idx, ok := slices.BinarySearchFunc(chunk, rdr.offset, func(r tokenRecord[S, V], offset int) int {
+				return r.start - offset
+			})
+
+			if ok {
+				segments = append(segments, rescanned, chunk[idx:])
+				rescanned = nil
+				rdr.offset = chunk[len(chunk)-1].end
+
+				break
+			}
+
+			if idx == len(chunk) {
+				break
+			}
+
+			rescanned = append(rescanned, s.scanRecord(rdr))
+		}
+	}
+
+	for rdr.offset < len(input) {
+		rescanned = append(rescanned, s.scanRecord(rdr))
+	}
+
+	rescanned = append(rescanned, tokenRecord[S, V]{value: s.lexer.eof, start: len(input), end: len(input)})
+
+	return append(segments, rescanned)
+}
+
+// Returns the tokens of the records in segments.
+// The location of the tokens is calculated concurrently per segment: first relative to the current position, and then
+// moved to the end of the preceding segment.
+func (s *Scanner[S, V]) locate(input []S, segments [][]tokenRecord[S, V]) []Token[S, V] {
+	size := 0
+
+	for _, segment := range segments {
+		size += len(segment)
+	}
+
+	tokens := make([]Token[S, V], size)
+	groups := make([][]Token[S, V], len(segments))
+
+	for idx, offset := 0, 0; idx < len(segments); idx++ {
+		groups[idx] = tokens[offset : offset+len(segments[idx])]
+		offset += len(segments[idx])
+	}
+
+	var wg sync.WaitGroup
+
+	for idx, segment := range segments {
+		wg.Go(func() {
+			p, prev := s.currentPos, rune(0)
+
+			for tIdx, r := range segment {
+				if tIdx == 0 {
+					prev = s.runeBefore(input, r.start)
+				}
+
+				token := &groups[idx][tIdx]
+				*token = Token[S, V]{Value: r.value, Span: pos.Span{Start: p}, Lookahead: r.lookahead}
+
+				if r.end > r.start {
+					token.Lexeme = input[r.start:r.end:r.end]
+				}
+
+				for _, sym := range token.Lexeme {
+					prev = advance(&p, s.lexer.terminators, prev, sym)
+				}
+
+				token.Span.End = p
+			}
+		})
+	}
+
+	wg.Wait()
+
+	starts := make([]pos.Position, len(groups))
+	starts[0] = s.currentPos
+
+	for idx := 1; idx < len(groups); idx++ {
+		starts[idx] = starts[idx-1]
+
+		if prev := groups[idx-1]; len(prev) > 0 {
+			starts[idx] = shiftPosition(prev[len(prev)-1].Span.End, s.currentPos, starts[idx-1])
+		}
+	}
+
+	for idx := 1; idx < len(groups); idx++ {
+		wg.Go(func() {
+			shiftTokens(groups[idx], s.currentPos, starts[idx])
+		})
+	}
+
+	wg.Wait()
+
+	return tokens
+}
+
+// Runs the actions of the records in segments (in order) for tokens, and returns the tokens that aren't dropped.
+func (s *Scanner[S, V]) runActions(tokens []Token[S, V], segments [][]tokenRecord[S, V]) []Token[S, V] {
+	kept, idx := tokens[:0], 0
+
+	for _, segment := range segments {
+		for _, r := range segment {
+			if token, ok := s.act(tokens[idx], r.action); ok {
+				kept = append(kept, token)
+			}
+
+			idx++
+		}
+	}
+
+	return kept
+}
+
+// Returns the offsets where input is split into the given amount of chunks (including 0 and the size of input).
+// Each offset is moved right after the next line break (if any), since a token is likely to start there.
+func splitBounds[S comparable](input []S, chunks int) []int {
+	bounds := []int{0}
+
+	for idx := 1; idx < chunks; idx++ {
+		bound := max(idx*len(input)/chunks, bounds[len(bounds)-1])
+
+		for offset := bound; offset < min(bound+maxBoundarySearch, len(input)); offset++ {
+			if r, ok := runeOf(input[offset]); ok && r == '\n' {
+				bound = offset + 1
+
+				break
+			}
+		}
+
+		if bound > bounds[len(bounds)-1] && bound < len(input) {
+			bounds = append(bounds, bound)
+		}
+	}
+
+	return append(bounds, len(input))
+}
@@ -0,0 +1,146 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "scanner" package.
+package scanner_test
+
+import (
+	"math/rand"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/scanner"
+)
+
+// UT: Scan an input in parallel with an invalid amount of chunks.
+func TestScanner_ScanParallelPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	handler := func() {
+		newPushTestScanner().ScanParallel([]rune("a"), 0)
+	}
+
+	// Act / assert.
+	assert.Panicf(t, handler, "\n\n"+
+		"UT Name:  Scanning an input in parallel with less than 1 chunk causes a panic.\n"+
+		"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+		"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+}
+
+// UT: Compare the tokens of [scanner.Scanner.ScanParallel] with the tokens of a sequential [scanner.Scanner].
+func TestScanner_ScanParallel(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	rnd := rand.New(rand.NewSource(34))
+	alphabet := []rune("ab1. */\n?")
+
+	for range 200 {
+		input := make([]rune, rnd.Intn(200))
+
+		for idx := range input {
+			input[idx] = alphabet[rnd.Intn(len(alphabet))]
+		}
+
+		chunks := 1 + rnd.Intn(8)
+
+		// Act.
+		got := formatTokensWithLookahead(newPushTestScanner().ScanParallel(input, chunks))
+		want := formatTokensWithLookahead(readAllTokens(newPushTestScanner(), newSliceReader(input)))
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning %q in %d chunks produces the same tokens as scanning it sequentially.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", string(input), chunks, want, got)
+	}
+}
+
+// Benchmark: Scan a large input sequentially.
+// The tokens own their lexemes (see [scanner.Scanner.Next]), and they're collected into a slice of the exact size, like
+// the ones of [BenchmarkScanParallel].
+func BenchmarkScanSequential(b *testing.B) {
+	s := newPushTestScanner()
+	input := generateSource(1 << 20)
+	count := len(readAllTokens(s, newSliceReader(input)))
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(input)))
+
+	for b.Loop() {
+		s.Reset()
+		rdr := newSliceReader(input)
+		tokens := make([]scanner.Token[rune, string], 0, count)
+
+		for token := s.Next(rdr); ; token = s.Next(rdr) {
+			if tokens = append(tokens, token); token.Value == "EOF" {
+				break
+			}
+		}
+	}
+}
+
+// Benchmark: Scan a large input in parallel, using a chunk per CPU (run with "-cpu 1,4,8" to compare).
+// The lexemes are copied, so that the tokens own their lexemes, like the ones of [BenchmarkScanSequential].
+func BenchmarkScanParallel(b *testing.B) {
+	s := newPushTestScanner()
+	input := generateSource(1 << 20)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(input)))
+
+	for b.Loop() {
+		s.Reset()
+		tokens := s.ScanParallel(input, runtime.GOMAXPROCS(0))
+
+		for idx := range tokens {
+			tokens[idx].Lexeme = slices.Clone(tokens[idx].Lexeme)
+		}
+	}
+}
+
+// Returns an input of (at least) size symbols with lines of identifiers, numbers, operators and comments.
+func generateSource(size int) []rune {
+	rnd := rand.New(rand.NewSource(int64(size)))
+	words := []string{"alpha", "beta", "111", "...", ".", "/", "*", "/* a\n/* b */ */", "ab1"}
+
+	var sb strings.Builder
+
+	for sb.Len() < size {
+		sb.WriteString(words[rnd.Intn(len(words))])
+
+		if rnd.Intn(8) == 0 {
+			sb.WriteRune('\n')
+		} else {
+			sb.WriteRune(' ')
+		}
+	}
+
+	return []rune(sb.String())
+}
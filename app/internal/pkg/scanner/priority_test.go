@@ -0,0 +1,132 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "scanner" package.
+package scanner_test
+
+import (
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/scanner"
+)
+
+// UT: Resolve the patterns that match the same token by their priority.
+func TestScanner_Priority(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		options []scanner.PatternOption[rune, string] // The options of the keyword, which is added last.
+		want    []string
+	}{
+		"Without a priority, the pattern that's added first wins.": {
+			want: newSlice("IDENT(if)@1:1-1:3", "WS( )@1:3-1:4", "IDENT(iff)@1:4-1:7", "EOF()@1:7-1:7"),
+		},
+		"With a higher priority, the pattern that's added last wins.": {
+			options: newSlice(scanner.WithPriority[rune, string](1)),
+			want:    newSlice("IF(if)@1:1-1:3", "WS( )@1:3-1:4", "IDENT(iff)@1:4-1:7", "EOF()@1:7-1:7"),
+		},
+		"With a lower priority, the pattern that's added last loses.": {
+			options: newSlice(scanner.WithPriority[rune, string](-1)),
+			want:    newSlice("IDENT(if)@1:1-1:3", "WS( )@1:3-1:4", "IDENT(iff)@1:4-1:7", "EOF()@1:7-1:7"),
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			s := scanner.NewScannerBuilder[rune, string]().
+				Add(scanner.Literal[rune, string](' '), "WS").
+				Add(scanner.ASCIIIdentifier[string](), "IDENT").
+				Add(scanner.Literal[rune, string]('i', 'f'), "IF", tc.options...).
+				Build("ILLEGAL", "EOF")
+
+			// Act.
+			got := formatTokens(readAllTokens(s, newSliceReader([]rune("if iff"))))
+
+			// Assert.
+			assert.EqualSf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Match a pattern with its shortest match.
+func TestScanner_ShortestMatch(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		options []scanner.PatternOption[rune, string] // The options of the comment.
+		want    []string
+	}{
+		"Without the shortest match, a comment ends at the last terminator.": {
+			want: newSlice("COMMENT(/* a */ b /* c */)@1:1-1:18+0", "EOF()@1:18-1:18+0"),
+		},
+		"With the shortest match, a comment ends at the first terminator.": {
+			options: newSlice(scanner.WithShortestMatch[rune, string]()),
+			want: newSlice(
+				"COMMENT(/* a */)@1:1-1:8+1", "WS( )@1:8-1:9+1", "IDENT(b)@1:9-1:10+1", "WS( )@1:10-1:11+1",
+				"COMMENT(/* c */)@1:11-1:18+0", "EOF()@1:18-1:18+0",
+			),
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			body := scanner.RepeatAtLeast(0, scanner.AnyOf(
+				scanner.Literal[rune, string]('a'),
+				scanner.Literal[rune, string]('b'),
+				scanner.Literal[rune, string]('c'),
+				scanner.Literal[rune, string](' '),
+				scanner.Literal[rune, string]('*'),
+				scanner.Literal[rune, string]('/'),
+			))
+
+			s := scanner.NewScannerBuilder[rune, string]().
+				Add(scanner.Sequence(
+					scanner.Literal[rune, string]('/', '*'), body, scanner.Literal[rune, string]('*', '/'),
+				), "COMMENT", tc.options...).
+				Add(scanner.Literal[rune, string](' '), "WS").
+				Add(scanner.ASCIIIdentifier[string](), "IDENT").
+				Build("ILLEGAL", "EOF")
+
+			// Act.
+			got := formatTokensWithLookahead(readAllTokens(s, newSliceReader([]rune("/* a */ b /* c */"))))
+
+			// Assert.
+			assert.EqualSf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a mechanism for transforming patterns into a set of tokens.
+package scanner
+
+import (
+	"errors"
+	"io"
+)
+
+// Errors returned by the [SymbolReader] of a [PushScanner].
+var (
+	errIncomplete  = errors.New("scanner: incomplete input")
+	errUnreadStart = errors.New("scanner: cannot unread before the start of the token")
+)
+
+// PushScanner performs lexical analysis on input that's delivered in chunks (for example, by an editor or a network
+// connection), instead of being read from a [SymbolReader].
+// It keeps the state of the automaton and the symbols of the token that's being matched between chunks, and it emits
+// tokens as soon as they are definitely complete. The tokens are identical to the ones produced by [Scanner.Next] for
+// the complete input.
+//
+// NOTE: A [Matcher] reads from the symbols that are fed so far. When it runs out of symbols, it's run again once more
+// symbols are fed.
+type PushScanner[S comparable, V any] struct {
+	scanner  *Scanner[S, V]
+	rdr      *chunkReader[S]
+	tRdr     *tokenReader[S]
+	matching matching[S, V]
+	eof      bool // Indicates whether the final token has been emitted.
+}
+
+// NewPushScanner returns a [PushScanner] that uses scanner to tokenize the chunks that are fed to it.
+func NewPushScanner[S comparable, V any](scanner *Scanner[S, V]) *PushScanner[S, V] {
+	rdr := &chunkReader[S]{}
+
+	return &PushScanner[S, V]{
+		scanner:  scanner,
+		rdr:      rdr,
+		tRdr:     &tokenReader[S]{rdr: rdr},
+		matching: scanner.newMatching(scanner.startAnchors()),
+	}
+}
+
+// Feed appends chunk to the input and returns the tokens that are complete.
+// Panics if the scanner is closed.
+func (ps *PushScanner[S, V]) Feed(chunk []S) []Token[S, V] {
+	if ps.rdr.closed {
+		panic("Feed: scanner is closed")
+	}
+
+	ps.rdr.symbols = append(ps.rdr.symbols, chunk...)
+
+	return ps.emit(nil)
+}
+
+// Close marks the end of the input and returns the remaining tokens, including the token with the final value.
+// Closing a scanner that's already closed returns nothing.
+func (ps *PushScanner[S, V]) Close() []Token[S, V] {
+	ps.rdr.closed = true
+	tokens := ps.emit(nil)
+
+	if !ps.eof {
+		ps.eof = true
+		tokens = append(tokens, ps.scanner.token(ps.scanner.lexer.eof, nil, 0))
+	}
+
+	return tokens
+}
+
+// Appends the tokens that are complete to tokens and returns the result.
+func (ps *PushScanner[S, V]) emit(tokens []Token[S, V]) []Token[S, V] {
+	for ps.rdr.offset < len(ps.rdr.symbols) || ps.tRdr.offset > 0 {
+		if err := ps.scanner.step(ps.tRdr, &ps.matching); err == errIncomplete {
+			return tokens
+		}
+
+		ps.rdr.starved = false
+		value, length, action := ps.scanner.complete(ps.tRdr, &ps.matching)
+
+		if ps.rdr.starved {
+			return tokens
+		}
+
+		token, ok := ps.scanner.act(ps.scanner.token(value, ps.tRdr.symbols[:length], len(ps.tRdr.symbols)-length), action)
+
+		if ok {
+			tokens = append(tokens, token)
+		}
+
+		ps.rdr.symbols = ps.rdr.symbols[length:]
+		ps.rdr.offset -= length
+		ps.tRdr.symbols, ps.tRdr.offset = ps.tRdr.symbols[:0], 0
+		ps.matching = ps.scanner.newMatching(ps.scanner.startAnchors())
+	}
+
+	return tokens
+}
+
+// The [SymbolReader] of a [PushScanner], which reads the symbols (starting at the token that's being matched) that are
+// fed so far.
+type chunkReader[S comparable] struct {
+	symbols []S
+	offset  int
+	closed  bool // Indicates whether NO more symbols will be fed.
+	starved bool // Indicates whether a symbol is requested beyond the symbols that are fed so far.
+}
+
+// ReadSymbol reads the next symbol.
+// Returns errIncomplete if more symbols are needed or [io.EOF] if the input is exhausted.
+func (cRdr *chunkReader[S]) ReadSymbol() (S, error) {
+	var sym S
+
+	if cRdr.offset == len(cRdr.symbols) {
+		if cRdr.closed {
+			return sym, io.EOF
+		}
+
+		cRdr.starved = true
+
+		return sym, errIncomplete
+	}
+
+	cRdr.offset++
+
+	return cRdr.symbols[cRdr.offset-1], nil
+}
+
+// UnreadSymbol unreads the last symbol read.
+func (cRdr *chunkReader[S]) UnreadSymbol() error {
+	if cRdr.offset == 0 {
+		return errUnreadStart
+	}
+
+	cRdr.offset--
+
+	return nil
+}
@@ -0,0 +1,223 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "scanner" package.
+package scanner_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/scanner"
+)
+
+// UT: Feed a [scanner.PushScanner] that's closed.
+func TestPushScanner_FeedPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	ps := scanner.NewPushScanner(newPushTestScanner())
+	ps.Close()
+
+	handler := func() {
+		ps.Feed([]rune("a"))
+	}
+
+	// Act / assert.
+	assert.Panicf(t, handler, "\n\n"+
+		"UT Name:  Feeding a 'PushScanner' that's closed causes a panic.\n"+
+		"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+		"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+}
+
+// UT: Feed chunks to a [scanner.PushScanner].
+func TestPushScanner(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		chunks []string
+		want   [][]string // The tokens emitted per chunk, followed by the tokens emitted by 'Close'.
+	}{
+		"Closing a 'PushScanner' without feeding it produces the final value.": {
+			chunks: newSlice[string](),
+			want:   newSlice(newSlice("EOF()@1:1-1:1")),
+		},
+		"Feeding a token that can still be extended doesn't produce it.": {
+			chunks: newSlice("ab", "c"),
+			want:   newSlice(newSlice[string](), newSlice[string](), newSlice("IDENT(abc)@1:1-1:4", "EOF()@1:4-1:4")),
+		},
+		"Feeding a symbol that can't extend a token produces the token.": {
+			chunks: newSlice("ab 1", "1."),
+			want: newSlice(
+				newSlice("IDENT(ab)@1:1-1:3", "WS( )@1:3-1:4"),
+				newSlice("NUMBER(11)@1:4-1:6"),
+				newSlice("DOT(.)@1:6-1:7", "EOF()@1:7-1:7"),
+			),
+		},
+		"Feeding a prefix of a longer token, that turns out to be invalid, produces the shorter tokens.": {
+			chunks: newSlice("..", "x"),
+			want: newSlice(
+				newSlice[string](),
+				newSlice("DOT(.)@1:1-1:2", "DOT(.)@1:2-1:3"),
+				newSlice("IDENT(x)@1:3-1:4", "EOF()@1:4-1:4"),
+			),
+		},
+		"Feeding a token that's completed by a 'Matcher' produces it once it's complete.": {
+			chunks: newSlice("/* a /* b", " */", " */x"),
+			want: newSlice(
+				newSlice[string](),
+				newSlice[string](),
+				newSlice("COMMENT(/* a /* b */ */)@1:1-1:16"),
+				newSlice("IDENT(x)@1:16-1:17", "EOF()@1:17-1:17"),
+			),
+		},
+		"Closing a 'PushScanner' in the middle of a token produces the tokens of the remaining input.": {
+			chunks: newSlice("/* a", "?"),
+			want: newSlice(
+				newSlice[string](),
+				newSlice[string](),
+				newSlice("DIV(/)@1:1-1:2", "MUL(*)@1:2-1:3", "WS( )@1:3-1:4", "IDENT(a)@1:4-1:5", "ILLEGAL(?)@1:5-1:6", "EOF()@1:6-1:6"),
+			),
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			ps := scanner.NewPushScanner(newPushTestScanner())
+
+			// Act.
+			var got [][]string
+
+			for _, chunk := range tc.chunks {
+				got = append(got, formatTokens(ps.Feed([]rune(chunk))))
+			}
+
+			got = append(got, formatTokens(ps.Close()))
+
+			// Assert.
+			assert.Equalf(t, len(got), len(tc.want), "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, tc.want, got)
+
+			for idx := range got {
+				assert.EqualSf(t, got[idx], tc.want[idx], "\n\n"+
+					"UT Name:  %s\n"+
+					"\033[32mExpected (call %d): %v.\033[0m\n"+
+					"\033[31mActual (call %d):   %v.\033[0m\n\n", tcName, idx, tc.want[idx], idx, got[idx])
+			}
+		})
+	}
+
+	t.Run("Closing a 'PushScanner' that's already closed produces nothing.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		ps := scanner.NewPushScanner(newPushTestScanner())
+		ps.Feed([]rune("a"))
+		ps.Close()
+
+		// Act.
+		got := formatTokens(ps.Close())
+
+		// Assert.
+		assert.EqualSf(t, got, nil, "\n\n"+
+			"UT Name:  Closing a 'PushScanner' that's already closed produces nothing.\n"+
+			"\033[32mExpected: [].\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", got)
+	})
+}
+
+// UT: Compare the tokens of a [scanner.PushScanner] with the tokens of a one-shot [scanner.Scanner].
+func TestPushScanner_Differential(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	rnd := rand.New(rand.NewSource(32))
+	alphabet := []rune("ab1. */\n?")
+
+	for range 500 {
+		input := make([]rune, rnd.Intn(40))
+
+		for idx := range input {
+			input[idx] = alphabet[rnd.Intn(len(alphabet))]
+		}
+
+		ps := scanner.NewPushScanner(newPushTestScanner())
+
+		// Act.
+		var got []string
+
+		for rest := input; len(rest) > 0; {
+			size := min(1+rnd.Intn(5), len(rest))
+			got = append(got, formatTokens(ps.Feed(rest[:size]))...)
+			rest = rest[size:]
+		}
+
+		got = append(got, formatTokens(ps.Close())...)
+		want := formatTokens(readAllTokens(newPushTestScanner(), newSliceReader(input)))
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Feeding %q in chunks produces the same tokens as scanning it at once.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", string(input), want, got)
+	}
+}
+
+// Returns a [scanner.Scanner] with patterns that share prefixes and a pattern with a [scanner.Matcher].
+func newPushTestScanner() *scanner.Scanner[rune, string] {
+	return scanner.NewScannerBuilder[rune, string]().
+		Add(scanner.Literal[rune, string]('/', '*'), "COMMENT", scanner.WithMatcher[rune, string](nestedComment)).
+		Add(scanner.Literal[rune, string]('/'), "DIV").
+		Add(scanner.Literal[rune, string]('*'), "MUL").
+		Add(scanner.Literal[rune, string]('.', '.', '.'), "ELLIPSIS").
+		Add(scanner.Literal[rune, string]('.'), "DOT").
+		Add(scanner.RepeatAtLeast(1, scanner.Literal[rune, string]('1')), "NUMBER").
+		Add(scanner.ASCIIIdentifier[string](), "IDENT").
+		Add(scanner.RepeatAtLeast(1, scanner.AnyOf(
+			scanner.Literal[rune, string](' '),
+			scanner.Literal[rune, string]('\n'),
+		)), "WS").
+		Build("ILLEGAL", "EOF")
+}
+
+// Read the tokens of rdr (including the token with the final value).
+func readAllTokens(s *scanner.Scanner[rune, string], rdr scanner.SymbolReader[rune]) []scanner.Token[rune, string] {
+	var tokens []scanner.Token[rune, string]
+
+	for {
+		token := s.Next(rdr)
+		tokens = append(tokens, token)
+
+		if token.Value == "EOF" {
+			return tokens
+		}
+	}
+}
@@ -26,6 +26,8 @@
 // Package scanner implements a mechanism for transforming patterns into a set of tokens.
 package scanner
 
+import "io"
+
 // SymbolReader is the interface used by the [Scanner] to read and unread symbols from the underlying input source.
 // It allows the [Scanner] to work with stream-based input (like files or network connections) rather than just
 // in-memory slices.
@@ -36,3 +38,57 @@ type SymbolReader[S comparable] interface {
 	// UnreadSymbol pushes the previously read symbol back onto the input stream, allowing it to be read again.
 	UnreadSymbol() error
 }
+
+// MultiUnreader is implemented by a [SymbolReader] that can unread multiple symbols at once. It's used to rewind a
+// [Scanner] to a [Mark] efficiently.
+type MultiUnreader interface {
+	// UnreadSymbols pushes the previously read n symbols back onto the input stream, allowing them to be read again.
+	UnreadSymbols(n int) error
+}
+
+// SliceReader is a [SymbolReader] that reads the symbols of an in-memory input.
+// It can unread any amount of symbols at once (see [SliceReader.UnreadSymbols]).
+type SliceReader[S comparable] struct {
+	input  []S
+	offset int
+}
+
+// NewSliceReader returns a [SliceReader] that reads the symbols of input, starting at its first symbol.
+func NewSliceReader[S comparable](input []S) *SliceReader[S] {
+	return &SliceReader[S]{input: input}
+}
+
+// ReadSymbol reads the next symbol.
+func (sRdr *SliceReader[S]) ReadSymbol() (S, error) {
+	if sRdr.offset == len(sRdr.input) {
+		var sym S
+
+		return sym, io.EOF
+	}
+
+	sRdr.offset++
+
+	return sRdr.input[sRdr.offset-1], nil
+}
+
+// UnreadSymbol unreads the last symbol read.
+func (sRdr *SliceReader[S]) UnreadSymbol() error {
+	if sRdr.offset == 0 {
+		return io.ErrUnexpectedEOF
+	}
+
+	sRdr.offset--
+
+	return nil
+}
+
+// UnreadSymbols unreads the last n symbols read.
+func (sRdr *SliceReader[S]) UnreadSymbols(n int) error {
+	if n > sRdr.offset {
+		return io.ErrUnexpectedEOF
+	}
+
+	sRdr.offset -= n
+
+	return nil
+}
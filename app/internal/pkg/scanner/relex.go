@@ -0,0 +1,251 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a mechanism for transforming patterns into a set of tokens.
+package scanner
+
+import (
+	"io"
+	"slices"
+
+	"github.com/kdeconinck/align/internal/pkg/pos"
+)
+
+// Edit describes a change of the input: the symbols at Span are replaced by Text.
+type Edit[S comparable] struct {
+	// Span is the location of the symbols that are replaced.
+	Span pos.Span
+
+	// Text contains the symbols that replace the ones at Span.
+	Text []S
+}
+
+// TokenChange describes the range of tokens that's changed by [Scanner.Relex].
+// The tokens before Start are the same in both lists, and so are the tokens after OldEnd (in the old list) and NewEnd
+// (in the new list), apart from their location.
+type TokenChange struct {
+	// Start is the index of the first token that's changed.
+	Start int
+
+	// OldEnd is the index (in the old list) after the last token that's replaced.
+	OldEnd int
+
+	// NewEnd is the index (in the new list) after the last token that's inserted.
+	NewEnd int
+}
+
+// Relex returns the tokens of the input after applying edit, given the tokens of the input before the edit, and the
+// range of tokens that changed.
+// Only the tokens that are affected by the edit are scanned again: scanning starts at the first token that might have
+// read a symbol of the edit (see [Token.Lookahead]), and stops as soon as a token starts where a token of the old list
+// started after the edit (after a '\r' if and only if the old token did, and at the start of a line if and only if the
+// old token did, when a pattern starts with [LineStart] or [InputStart]). From there on, the old tokens are reused
+// (with an updated location).
+// The old tokens must be the complete output of a [Scanner] (with the same patterns), including the final token.
+// The current position of the scanner is moved to the end of the input.
+// Panics if the span of edit isn't part of the input.
+//
+// NOTE: The input is reconstructed from the lexemes of the tokens, so the input itself isn't needed. For the same
+// reason, the patterns must NOT have an action that drops tokens (see [ErrSkipToken]).
+func (s *Scanner[S, V]) Relex(tokens []Token[S, V], edit Edit[S]) ([]Token[S, V], TokenChange) {
+	starts := make([]int, len(tokens)+1) // The offset of each token and of the end of the input.
+
+	for idx, token := range tokens {
+		starts[idx+1] = starts[idx] + len(token.Lexeme)
+	}
+
+	size := starts[len(tokens)]
+	from := offsetOf(tokens, starts, edit.Span.Start, s.lexer.terminators)
+	to := offsetOf(tokens, starts, edit.Span.End, s.lexer.terminators)
+
+	if from > to {
+		panic("Relex: span of edit ends before it starts")
+	}
+
+	restart := 0
+
+	for restart < len(tokens)-1 {
+		examined := starts[restart+1] + tokens[restart].Lookahead
+
+		if from < examined || examined >= size {
+			break
+		}
+
+		restart++
+	}
+
+	rdr := &editReader[S, V]{tokens: tokens, starts: starts, edit: edit, from: from, to: to, offset: starts[restart]}
+	result := slices.Clone(tokens[:restart])
+	s.currentPos, s.offset, s.prev = tokens[restart].Span.Start, starts[restart], lastRune(tokens[:restart])
+
+	for {
+		if rdr.offset >= from+len(edit.Text) {
+			old, ok := slices.BinarySearch(starts[:len(tokens)], rdr.offset-from-len(edit.Text)+to)
+
+			if ok && s.canResume(tokens, old) {
+				change := TokenChange{Start: restart, OldEnd: old, NewEnd: len(result)}
+				result = append(result, tokens[old:]...)
+				shiftTokens(result[change.NewEnd:], tokens[old].Span.Start, s.currentPos)
+				s.currentPos, s.offset = result[len(result)-1].Span.End, size-to+from+len(edit.Text)
+				s.prev = lastRune(result)
+
+				return result, change
+			}
+		}
+
+		token := s.Next(rdr)
+		result = append(result, token)
+
+		if len(token.Lexeme) == 0 {
+			return result, TokenChange{Start: restart, OldEnd: len(tokens), NewEnd: len(result)}
+		}
+	}
+}
+
+// Reports whether the old token at index old can be reused at the current position of s.
+// That's the case if the location of the token (and the ones after it) can be moved to the current position: both are
+// preceded by a '\r' (which decides whether a '\n' completes a "\r\n") or neither is. Besides, when a pattern starts
+// with a start anchor, both must be at the start of a line or neither.
+func (s *Scanner[S, V]) canResume(tokens []Token[S, V], old int) bool {
+	if (lastRune(tokens[:old]) == '\r') != (s.prev == '\r') {
+		return false
+	}
+
+	return !s.lexer.startAnchored || startAnchorsAt(tokens[old].Span.Start) == s.startAnchors()
+}
+
+// Returns the last symbol (as a rune) of the lexemes of tokens, or 0 if there's none.
+func lastRune[S comparable, V any](tokens []Token[S, V]) rune {
+	for idx := len(tokens) - 1; idx >= 0; idx-- {
+		if lexeme := tokens[idx].Lexeme; len(lexeme) > 0 {
+			r, _ := runeOf(lexeme[len(lexeme)-1])
+
+			return r
+		}
+	}
+
+	return 0
+}
+
+// Returns the offset of p, which is located in tokens (where the offset of each token is in starts), where lines end
+// with one of the line terminators in terminators.
+// Panics if p isn't part of the input.
+func offsetOf[S comparable, V any](tokens []Token[S, V], starts []int, p pos.Position, terminators pos.Terminators) int {
+	idx, _ := slices.BinarySearchFunc(tokens, p, func(token Token[S, V], p pos.Position) int {
+		return token.Span.End.Compare(p)
+	})
+
+	if idx == len(tokens) {
+		panic("Relex: span of edit is outside of the input")
+	}
+
+	current, prev := tokens[idx].Span.Start, lastRune(tokens[:idx])
+
+	for offset, sym := range tokens[idx].Lexeme {
+		if current == p {
+			return starts[idx] + offset
+		}
+
+		prev = advance(&current, terminators, prev, sym)
+	}
+
+	if current != p {
+		panic("Relex: span of edit is outside of the input")
+	}
+
+	return starts[idx+1]
+}
+
+// Moves tokens from oldStart (the start of the first token) to newStart.
+func shiftTokens[S comparable, V any](tokens []Token[S, V], oldStart, newStart pos.Position) {
+	for idx := range tokens {
+		tokens[idx].Span.Start = shiftPosition(tokens[idx].Span.Start, oldStart, newStart)
+		tokens[idx].Span.End = shiftPosition(tokens[idx].Span.End, oldStart, newStart)
+	}
+}
+
+// Returns p, which is located relative to oldStart, moved relative to newStart.
+func shiftPosition(p, oldStart, newStart pos.Position) pos.Position {
+	if p.Line == oldStart.Line {
+		p.Column += newStart.Column - oldStart.Column
+	}
+
+	p.Line += newStart.Line - oldStart.Line
+	p.Offset += newStart.Offset - oldStart.Offset
+
+	return p
+}
+
+// A [SymbolReader] that reads the input after applying an edit, where the input before the edit is made up of the
+// lexemes of tokens (where the offset of each token is in starts).
+type editReader[S comparable, V any] struct {
+	tokens   []Token[S, V]
+	starts   []int
+	edit     Edit[S]
+	from, to int // The offsets of the symbols that are replaced by the edit.
+	offset   int // The offset in the input after applying the edit.
+	token    int // The index of the token that contains the last symbol that's read from the old input.
+}
+
+// ReadSymbol reads the next symbol.
+func (eRdr *editReader[S, V]) ReadSymbol() (S, error) {
+	offset := eRdr.offset
+
+	if offset >= eRdr.from {
+		if offset < eRdr.from+len(eRdr.edit.Text) {
+			eRdr.offset++
+
+			return eRdr.edit.Text[offset-eRdr.from], nil
+		}
+
+		offset += eRdr.to - eRdr.from - len(eRdr.edit.Text)
+	}
+
+	if offset >= eRdr.starts[len(eRdr.tokens)] {
+		var sym S
+
+		return sym, io.EOF
+	}
+
+	if offset < eRdr.starts[eRdr.token] || offset >= eRdr.starts[eRdr.token+1] {
+		eRdr.token, _ = slices.BinarySearch(eRdr.starts, offset+1)
+		eRdr.token--
+	}
+
+	eRdr.offset++
+
+	return eRdr.tokens[eRdr.token].Lexeme[offset-eRdr.starts[eRdr.token]], nil
+}
+
+// UnreadSymbol unreads the last symbol read.
+func (eRdr *editReader[S, V]) UnreadSymbol() error {
+	if eRdr.offset == 0 {
+		return io.ErrUnexpectedEOF
+	}
+
+	eRdr.offset--
+
+	return nil
+}
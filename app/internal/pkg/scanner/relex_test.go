@@ -0,0 +1,232 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "scanner" package.
+package scanner_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/pos"
+	"github.com/kdeconinck/align/internal/pkg/scanner"
+)
+
+// UT: Relex the tokens of an input with an invalid edit.
+func TestScanner_RelexPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		span pos.Span
+	}{
+		"Relexing with an edit that's located after the input causes a panic.": {
+			span: pos.Span{Start: pos.Position{Line: 1, Column: 2, Offset: 1}, End: pos.Position{Line: 2, Column: 1, Offset: 5}},
+		},
+		"Relexing with an edit that ends before it starts causes a panic.": {
+			span: pos.Span{Start: pos.Position{Line: 1, Column: 3, Offset: 2}, End: pos.Position{Line: 1, Column: 2, Offset: 1}},
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			s := newPushTestScanner()
+			tokens := readAllTokens(s, newSliceReader([]rune("ab c")))
+
+			handler := func() {
+				s.Relex(tokens, scanner.Edit[rune]{Span: tc.span})
+			}
+
+			// Act / assert.
+			assert.Panicf(t, handler, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+				"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n", tcName)
+		})
+	}
+}
+
+// UT: Relex the tokens of an input after an edit.
+func TestScanner_Relex(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		input      string
+		start, end int // The offsets of the symbols that are replaced.
+		text       string
+		want       []string
+		wantChange scanner.TokenChange
+	}{
+		"Replacing a token only scans the tokens around it again.": {
+			input: "ab cd ef", start: 3, end: 5, text: "xy",
+			want: newSlice(
+				"IDENT(ab)@1:1-1:3", "WS( )@1:3-1:4", "IDENT(xy)@1:4-1:6", "WS( )@1:6-1:7", "IDENT(ef)@1:7-1:9", "EOF()@1:9-1:9",
+			),
+			wantChange: scanner.TokenChange{Start: 1, OldEnd: 3, NewEnd: 3},
+		},
+		"Removing the symbols between two tokens merges them.": {
+			input: "ab cd", start: 2, end: 3,
+			want:       newSlice("IDENT(abcd)@1:1-1:5", "EOF()@1:5-1:5"),
+			wantChange: scanner.TokenChange{Start: 0, OldEnd: 3, NewEnd: 1},
+		},
+		"Inserting a line moves the tokens after it.": {
+			input: "ab cd ef", start: 3, end: 3, text: "x\n",
+			want: newSlice(
+				"IDENT(ab)@1:1-1:3", "WS( )@1:3-1:4", "IDENT(x)@1:4-1:5", "WS(\n)@1:5-2:1",
+				"IDENT(cd)@2:1-2:3", "WS( )@2:3-2:4", "IDENT(ef)@2:4-2:6", "EOF()@2:6-2:6",
+			),
+			wantChange: scanner.TokenChange{Start: 1, OldEnd: 2, NewEnd: 4},
+		},
+		"Opening a comment scans the input again until the comment ends.": {
+			input: "a /b c */ d", start: 2, end: 2, text: "/*",
+			want: newSlice(
+				"IDENT(a)@1:1-1:2", "WS( )@1:2-1:3", "COMMENT(/*/b c */)@1:3-1:12", "WS( )@1:12-1:13", "IDENT(d)@1:13-1:14",
+				"EOF()@1:14-1:14",
+			),
+			wantChange: scanner.TokenChange{Start: 1, OldEnd: 9, NewEnd: 3},
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			s := newPushTestScanner()
+			input := []rune(tc.input)
+			tokens := readAllTokens(s, newSliceReader(input))
+			edit := scanner.Edit[rune]{Span: spanOf(input, tc.start, tc.end), Text: []rune(tc.text)}
+
+			// Act.
+			result, gotChange := s.Relex(tokens, edit)
+			got := formatTokens(result)
+
+			// Assert.
+			assert.EqualSf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %v.\033[0m\n"+
+				"\033[31mActual:   %v.\033[0m\n\n", tcName, tc.want, got)
+
+			assert.Equalf(t, gotChange, tc.wantChange, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected (change): %+v.\033[0m\n"+
+				"\033[31mActual (change):   %+v.\033[0m\n\n", tcName, tc.wantChange, gotChange)
+		})
+	}
+}
+
+// UT: Compare the tokens of [scanner.Scanner.Relex] with the tokens of scanning the edited input.
+func TestScanner_RelexDifferential(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	rnd := rand.New(rand.NewSource(33))
+	alphabet := []rune("ab1. */\n?")
+	randomText := func(size int) []rune {
+		text := make([]rune, size)
+
+		for idx := range text {
+			text[idx] = alphabet[rnd.Intn(len(alphabet))]
+		}
+
+		return text
+	}
+
+	s := newPushTestScanner()
+	input := randomText(30)
+	tokens := readAllTokens(s, newSliceReader(input))
+
+	for range 1000 {
+		start := rnd.Intn(len(input) + 1)
+		end := start + rnd.Intn(len(input)-start+1)
+		text := randomText(rnd.Intn(4))
+		edit := scanner.Edit[rune]{Span: spanOf(input, start, end), Text: text}
+
+		input = append(append(append([]rune(nil), input[:start]...), text...), input[end:]...)
+
+		// Act.
+		result, change := s.Relex(tokens, edit)
+		got := formatTokensWithLookahead(result)
+		want := formatTokensWithLookahead(readAllTokens(newPushTestScanner(), newSliceReader(input)))
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Relexing %q produces the same tokens as scanning it again.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", string(input), want, got)
+
+		assert.EqualSf(t, got[:change.Start], formatTokensWithLookahead(tokens[:change.Start]), "\n\n"+
+			"UT Name:  Relexing %q keeps the tokens before the change.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", string(input), tokens[:change.Start], got[:change.Start])
+
+		assert.Equalf(t, len(result)-change.NewEnd, len(tokens)-change.OldEnd, "\n\n"+
+			"UT Name:  Relexing %q keeps the tokens after the change.\n"+
+			"\033[32mExpected: %d tokens after the change.\033[0m\n"+
+			"\033[31mActual:   %d tokens after the change.\033[0m\n\n", string(input), len(tokens)-change.OldEnd, len(result)-change.NewEnd)
+
+		assert.Equalf(t, s.Mark().Offset(), len(input), "\n\n"+
+			"UT Name:  Relexing %q moves the scanner to the end of the input.\n"+
+			"\033[32mExpected: offset %d.\033[0m\n"+
+			"\033[31mActual:   offset %d.\033[0m\n\n", string(input), len(input), s.Mark().Offset())
+
+		tokens = result
+	}
+}
+
+// Returns the span of the symbols of input between the offsets start and end.
+func spanOf(input []rune, start, end int) pos.Span {
+	span := pos.Span{Start: pos.New(), End: pos.New()}
+
+	for offset, r := range input[:end] {
+		if offset == start {
+			span.Start = span.End
+		}
+
+		span.End.Advance(r, utf8.RuneLen(r))
+	}
+
+	if start == end {
+		span.Start = span.End
+	}
+
+	return span
+}
+
+// Utility: Return the human-readable representation ("VALUE(lexeme)@start-end+lookahead") of tokens.
+func formatTokensWithLookahead[V any](tokens []scanner.Token[rune, V]) []string {
+	formatted := formatTokens(tokens)
+
+	for idx, token := range tokens {
+		formatted[idx] += fmt.Sprintf("+%d", token.Lookahead)
+	}
+
+	return formatted
+}
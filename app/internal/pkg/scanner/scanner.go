@@ -27,63 +27,285 @@
 package scanner
 
 import (
+	"errors"
+	"io"
+
 	"github.com/kdeconinck/align/internal/pkg/automata/dfa"
+	"github.com/kdeconinck/align/internal/pkg/automata/nfa"
 	"github.com/kdeconinck/align/internal/pkg/pos"
 )
 
 // Scanner performs a mechine for performing lexical analysis.
+// A Scanner holds the state of scanning a single input, while the (shared) automaton belongs to its [Lexer].
+// A Scanner is NOT safe for concurrent use.
 type Scanner[S comparable, V any] struct {
-	machine    *dfa.Dfa[S, V]
-	illegal    V            // The value to return for an unmatchable sequence.
-	eof        V            // The value to return when the input is fully consumed.
-	currentPos pos.Position // Tracking for the current position in the source.
+	lexer       *Lexer[S, V]
+	reader      tokenReader[S] // The reader of the current token, which holds the symbols consumed while matching it.
+	currentPos  pos.Position   // Tracking for the current position in the source.
+	offset      int            // The amount of symbols consumed from the start of the input.
+	prev        rune           // The last symbol consumed (as a rune), which decides whether a '\n' completes a "\r\n".
+	diagnostics []Diagnostic   // The diagnostics that are reported by actions.
+}
+
+// A rule describes what the [Scanner] does when the accepting state of a pattern is reached.
+type rule[S comparable, V any] struct {
+	value   V
+	matcher Matcher[S]   // Completes the token (optional).
+	action  Action[S, V] // Runs for each token that's matched (optional).
 }
 
-// NextToken reads from rdr from the current position and returns the next token that's matched by a pattern.
+// A (candidate) match for a token.
+type match struct {
+	length int // The amount of symbols in the token (-1 if there's no match).
+	rule   int // The index of the rule that matched.
+}
+
+// Reports whether m is preferred over other, according to the maximal munch rule.
+// If both matches have the same length, the one from the pattern with the highest priority (the lowest index) wins.
+func (m match) isBetterThan(other match) bool {
+	return m.length > other.length || m.length == other.length && m.rule < other.rule
+}
+
+// NextToken reads from rdr from the current position and returns the value of the next token that's matched by a
+// pattern.
 func (s *Scanner[S, V]) NextToken(rdr SymbolReader[S]) V {
-	currentState := s.machine.Start()
-	consumedSymbols := 0    // how many symbols we consumed for this token attempt
-	acceptSymbolCount := -1 // number of symbols consumed at last accepting state
+	for {
+		value, length, action := s.scan(rdr, s.startAnchors())
+
+		if action == nil {
+			s.advance(s.reader.symbols[:length])
+
+			return value
+		}
 
-	var lastAcceptVal V
+		token, ok := s.runAction(s.token(value, s.reader.symbols[:length], len(s.reader.symbols)-length), action)
 
+		if ok {
+			return token.Value
+		}
+	}
+}
+
+// Next reads from rdr from the current position and returns the next token that's matched by a pattern.
+// Once the input is exhausted, an empty token with the final value is returned.
+func (s *Scanner[S, V]) Next(rdr SymbolReader[S]) Token[S, V] {
 	for {
-		symbol, err := rdr.ReadSymbol()
+		value, length, action := s.scan(rdr, s.startAnchors())
+
+		token, ok := s.act(s.token(value, s.reader.symbols[:length], len(s.reader.symbols)-length), action)
+
+		if ok {
+			return token
+		}
+	}
+}
+
+// Returns the token with value which consists of symbols, starting at the current position, and advances the current
+// position over symbols. The amount of symbols that were read after the token is lookahead.
+func (s *Scanner[S, V]) token(value V, symbols []S, lookahead int) Token[S, V] {
+	token := Token[S, V]{Value: value, Span: pos.Span{Start: s.currentPos}, Lookahead: lookahead}
+
+	if len(symbols) > 0 {
+		token.Lexeme = append([]S(nil), symbols...)
+	}
+
+	s.advance(symbols)
+	token.Span.End = s.currentPos
+
+	return token
+}
+
+// Reads the next token from rdr, which starts where anchors hold, and returns its value, its length and the action of
+// its rule (if any).
+// The symbols of the token are stored at the start of the buffer of the scanner's reader.
+func (s *Scanner[S, V]) scan(rdr SymbolReader[S], anchors nfa.Anchor) (V, int, Action[S, V]) {
+	tRdr := &s.reader
+	*tRdr = tokenReader[S]{rdr: rdr, symbols: tRdr.symbols[:0]}
+
+	m := s.newMatching(anchors)
+	_ = s.step(tRdr, &m)
+
+	if tRdr.offset == 0 {
+		return s.lexer.eof, 0, nil
+	}
+
+	return s.complete(tRdr, &m)
+}
+
+// Advances the current position (and offset) of the scanner over symbols.
+func (s *Scanner[S, V]) advance(symbols []S) {
+	for _, sym := range symbols {
+		s.prev = advance(&s.currentPos, s.lexer.terminators, s.prev, sym)
+	}
+
+	s.offset += len(symbols)
+}
+
+// The progress of matching a token.
+type matching[S comparable, V any] struct {
+	state    *dfa.State[S, V] // The current state of the automaton (<nil> when NO other symbol can be matched).
+	best     match            // The best match that's complete.
+	pending  []match          // Prefixes that still have to be completed by a matcher.
+	cr       *dfa.State[S, V] // The state before a '\r' that ends a line if it's followed by '\n' (see [pos.CRLF]).
+	crLength int              // The amount of symbols consumed before that '\r'.
+}
+
+// Returns the progress of matching a token that starts where anchors hold, before any symbol is read.
+func (s *Scanner[S, V]) newMatching(anchors nfa.Anchor) matching[S, V] {
+	return matching[S, V]{state: s.lexer.machine.StartAt(anchors), best: match{length: -1}}
+}
+
+// Feeds the symbols of tRdr into the automaton of m, until it can't match another symbol.
+// After a '\r' that might end a line, the next symbol is read as well, to decide whether the end anchors hold.
+// Returns the error of tRdr if it can't provide another symbol before that (m can be resumed once it can).
+func (s *Scanner[S, V]) step(tRdr *tokenReader[S], m *matching[S, V]) error {
+	for m.state != nil || m.cr != nil {
+		symbol, err := tRdr.ReadSymbol()
 
 		if err != nil {
-			break
+			if errors.Is(err, io.EOF) && m.state != nil {
+				s.collectAnchoredMatches(m, m.state, tRdr.offset, nfa.EndAnchors)
+			}
+
+			if errors.Is(err, io.EOF) {
+				m.cr = nil
+			}
+
+			return err
+		}
+
+		if m.cr != nil {
+			if r, _ := runeOf(symbol); r == '\n' {
+				s.collectAnchoredMatches(m, m.cr, m.crLength, nfa.AnchorLineEnd)
+			}
+
+			if m.cr = nil; m.state == nil {
+				return nil
+			}
 		}
 
-		consumedSymbols++
-		nextState := currentState.OutgoingFor(symbol)
+		if m.state.AnchoredAccepts() != nil {
+			if anchors, crlf := s.endAnchorsBefore(symbol); crlf {
+				m.cr, m.crLength = m.state, tRdr.offset-1
+			} else {
+				s.collectAnchoredMatches(m, m.state, tRdr.offset-1, anchors)
+			}
+		}
+
+		m.state = m.state.OutgoingFor(symbol)
 
-		if nextState == nil {
-			break
+		if m.state != nil && m.state.IsAccepting() {
+			s.collectMatches(m, tRdr.offset)
 		}
+	}
+
+	return nil
+}
 
-		currentState = nextState
+// Completes the pending matches of m (by running their matchers) and returns the value and the length of the token,
+// and the action of its rule (if any).
+// Leaves tRdr positioned after the token.
+func (s *Scanner[S, V]) complete(tRdr *tokenReader[S], m *matching[S, V]) (V, int, Action[S, V]) {
+	best := m.best
 
-		if currentState.IsAccepting() {
-			acceptSymbolCount = consumedSymbols
-			lastAcceptVal = currentState.AcceptValue()
+	for _, p := range m.pending {
+		tRdr.seek(p.length)
+		length := runMatcher(s.lexer.rules[p.rule].matcher, tRdr.symbols[:p.length], tRdr)
+
+		if length != -1 && (match{length: p.length + length, rule: p.rule}).isBetterThan(best) {
+			best = match{length: p.length + length, rule: p.rule}
 		}
 	}
 
-	if consumedSymbols == 0 {
-		return s.eof
+	if best.length == -1 {
+		tRdr.seek(1)
+
+		return s.lexer.illegal, tRdr.offset, nil
+	}
+
+	tRdr.seek(best.length)
+
+	return s.lexer.rules[best.rule].value, best.length, s.lexer.rules[best.rule].action
+}
+
+// Records the matches of m, after reaching its (accepting) state after consuming length symbols.
+// The rules with a matcher are recorded as pending (regardless of their priority), since their matcher might extend
+// the token beyond the match of any rule without a matcher. Of the rules without a matcher, only the one with the
+// highest priority is recorded.
+func (s *Scanner[S, V]) collectMatches(m *matching[S, V], length int) {
+	if !s.lexer.matchers {
+		m.best = match{length: length, rule: m.state.AcceptIdx()}
+
+		return
+	}
+
+	var found bool
+
+	for _, idx := range m.state.AcceptIdxs() {
+		switch {
+		case s.lexer.rules[idx].matcher != nil:
+			m.pending = append(m.pending, match{length: length, rule: idx})
+
+		case !found:
+			m.best, found = match{length: length, rule: idx}, true
+		}
 	}
+}
+
+// A [SymbolReader] that records the symbols of the token that's being read from the wrapped [SymbolReader].
+type tokenReader[S comparable] struct {
+	rdr     SymbolReader[S]
+	symbols []S   // The symbols read from the start of the token (including the ones that were unread).
+	offset  int   // The offset of rdr, relative to the start of the token.
+	err     error // The first error (other than io.EOF) that's returned by rdr.
+}
+
+// ReadSymbol reads the next symbol from the wrapped reader.
+func (tRdr *tokenReader[S]) ReadSymbol() (S, error) {
+	sym, err := tRdr.rdr.ReadSymbol()
 
-	if acceptSymbolCount != -1 {
-		for idx := acceptSymbolCount; idx < consumedSymbols; idx++ {
-			_ = rdr.UnreadSymbol()
+	if err != nil {
+		if tRdr.err == nil && !errors.Is(err, io.EOF) {
+			tRdr.err = err
 		}
 
-		return lastAcceptVal
+		return sym, err
+	}
+
+	if tRdr.offset < len(tRdr.symbols) {
+		tRdr.symbols[tRdr.offset] = sym
+	} else {
+		tRdr.symbols = append(tRdr.symbols, sym)
+	}
+
+	tRdr.offset++
+
+	return sym, nil
+}
+
+// UnreadSymbol unreads the last symbol read from the wrapped reader.
+func (tRdr *tokenReader[S]) UnreadSymbol() error {
+	err := tRdr.rdr.UnreadSymbol()
+
+	if err == nil {
+		tRdr.offset--
 	}
 
-	for idx := 1; idx < consumedSymbols; idx++ {
-		_ = rdr.UnreadSymbol()
+	return err
+}
+
+// Moves the reader to offset to (by reading or unreading symbols).
+// The offset can be different from to when the wrapped reader is exhausted.
+func (tRdr *tokenReader[S]) seek(to int) {
+	for tRdr.offset > to {
+		if tRdr.UnreadSymbol() != nil {
+			return
+		}
 	}
 
-	return s.illegal
+	for tRdr.offset < to {
+		if _, err := tRdr.ReadSymbol(); err != nil {
+			return
+		}
+	}
 }
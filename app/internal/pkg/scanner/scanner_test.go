@@ -28,9 +28,12 @@ package scanner_test
 
 import (
 	"bufio"
+	"fmt"
 	"io"
+	"math/rand"
 	"strings"
 	"testing"
+	"unicode"
 
 	"github.com/kdeconinck/align/internal/pkg/assert"
 	"github.com/kdeconinck/align/internal/pkg/scanner"
@@ -458,6 +461,235 @@ func TestScanner(t *testing.T) {
 	})
 }
 
+// UT: Build a [scanner.Scanner] with patterns that exceed the state limit.
+func TestScannerBuilder_StateLimit(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("Building a 'Scanner' with a large bounded repetition that exceeds the limit returns an error.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		frag := scanner.RepeatBetween(1, 1_000_000, scanner.AnyOf(
+			scanner.Literal[rune, string]('a', 'b', 'c'),
+			scanner.RepeatAtLeast(1, scanner.Literal[rune, string]('x', 'y')),
+		))
+
+		builder := scanner.NewScannerBuilder[rune, string]().
+			SetStateLimit(10_000).
+			Add(frag, "REPEAT")
+
+		// Act.
+		_, got := builder.TryBuild("ILLEGAL", "EOF")
+
+		// Assert.
+		assert.Errorf(t, got, scanner.ErrStateLimit, "\n\n"+
+			"UT Name:  Building a 'Scanner' with a large bounded repetition that exceeds the limit returns an error.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", scanner.ErrStateLimit, got)
+	})
+
+	t.Run("Building a 'Scanner' with patterns that exceed the limit of the dfa returns an error.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange: (a|b)*a(a|b){8} requires an exponential amount of dfa states.
+		ab := scanner.AnyOf(scanner.Literal[rune, string]('a'), scanner.Literal[rune, string]('b'))
+		frag := scanner.Sequence(
+			scanner.RepeatAtLeast(0, ab),
+			scanner.Literal[rune, string]('a'),
+			scanner.RepeatBetween(8, 8, ab),
+		)
+
+		builder := scanner.NewScannerBuilder[rune, string]().
+			SetStateLimit(200).
+			Add(frag, "AB")
+
+		// Act.
+		_, got := builder.TryBuild("ILLEGAL", "EOF")
+
+		// Assert.
+		assert.Errorf(t, got, scanner.ErrStateLimit, "\n\n"+
+			"UT Name:  Building a 'Scanner' with patterns that exceed the limit of the dfa returns an error.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", scanner.ErrStateLimit, got)
+	})
+
+	t.Run("Building a 'Scanner' with patterns that exceed the limit causes a panic.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		handler := func() {
+			scanner.NewScannerBuilder[rune, string]().
+				SetStateLimit(10).
+				Add(scanner.RepeatBetween(1, 100, scanner.Literal[rune, string]('a')), "A").
+				Build("ILLEGAL", "EOF")
+		}
+
+		// Act / assert.
+		assert.Panicf(t, handler, "\n\n"+
+			"UT Name:  Building a 'Scanner' with patterns that exceed the limit causes a panic.\n"+
+			"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+			"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+	})
+
+	t.Run("Scanning a large bounded repetition within the limit produces the value.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s, err := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.RepeatBetween(1, 1000, scanner.Literal[rune, string]('a')), "A").
+			TryBuild("ILLEGAL", "EOF")
+
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  Scanning a large bounded repetition within the limit produces the value.\n"+
+			"\033[31mFatal error: Building the 'Scanner' failed: %v.\033[0m\n\n", err)
+
+		rdr := newSliceReader([]rune(strings.Repeat("a", 1001)))
+
+		// Act.
+		got, want := readN(s, rdr, 3), newSlice("A", "A", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning a large bounded repetition within the limit produces the value.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning a repetition of a fragment that matches nothing produces the value.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		frag := scanner.RepeatBetween(0, 3, scanner.RepeatAtLeast(0, scanner.Literal[rune, string]('a')))
+
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.RepeatAtLeast(0, frag), "A").
+			Build("ILLEGAL", "EOF")
+
+		rdr := newSliceReader([]rune("aaaa"))
+
+		// Act.
+		got, want := readN(s, rdr, 2), newSlice("A", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning a repetition of a fragment that matches nothing produces the value.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning input that requires more states for classes than the limit allows returns an error.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange: L*Lu L{16} requires an exponential amount of dfa states, which are constructed while scanning.
+		frag := scanner.Sequence(
+			scanner.RepeatAtLeast(0, scanner.InTable[string](unicode.L)),
+			scanner.InTable[string](unicode.Lu),
+			scanner.RepeatBetween(16, 16, scanner.InTable[string](unicode.L)),
+		)
+
+		s, err := scanner.NewScannerBuilder[rune, string]().
+			SetStateLimit(1_000).
+			Add(frag, "L").
+			TryBuild("ILLEGAL", "EOF")
+
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  Scanning input that requires more states for classes than the limit allows returns an error.\n"+
+			"\033[31mFatal error: Building the 'Scanner' failed: %v.\033[0m\n\n", err)
+
+		rnd := rand.New(rand.NewSource(29))
+		input := make([]rune, 20_000)
+
+		for idx := range input {
+			input[idx] = []rune("aA")[rnd.Intn(2)]
+		}
+
+		// Act.
+		_, got := scanner.Collect(s.All(newSliceReader(input)))
+
+		// Assert.
+		assert.Errorf(t, got, scanner.ErrStateLimit, "\n\n"+
+			"UT Name:  Scanning input that requires more states for classes than the limit allows returns an error.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", scanner.ErrStateLimit, got)
+	})
+}
+
+// UT: Build a [scanner.Scanner] and read the tokens of a given input.
+func TestScanner_Next(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("Reading tokens produces their lexeme and location.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.ASCIIIdentifier[string](), "IDENT").
+			Add(scanner.RepeatAtLeast(1, scanner.Literal[rune, string](' ')), "WS").
+			Add(scanner.Literal[rune, string]('\n'), "NL").
+			Build("ILLEGAL", "EOF")
+
+		rdr := newSliceReader([]rune("ab  c\n?d"))
+
+		// Act.
+		got := formatTokens(readTokensN(s, rdr, 8))
+		want := newSlice(
+			"IDENT(ab)@1:1-1:3", "WS(  )@1:3-1:5", "IDENT(c)@1:5-1:6", "NL(\n)@1:6-2:1",
+			"ILLEGAL(?)@2:1-2:2", "IDENT(d)@2:2-2:3", "EOF()@2:3-2:3", "EOF()@2:3-2:3",
+		)
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Reading tokens produces their lexeme and location.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Reading a token that's completed by a 'Matcher' produces the complete lexeme.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.Literal[rune, string]('/', '*'), "COMMENT", scanner.WithMatcher[rune, string](nestedComment)).
+			Add(scanner.ASCIIIdentifier[string](), "IDENT").
+			Build("ILLEGAL", "EOF")
+
+		rdr := newSliceReader([]rune("/* a\n/**/ */x"))
+
+		// Act.
+		got := formatTokens(readTokensN(s, rdr, 3))
+		want := newSlice("COMMENT(/* a\n/**/ */)@1:1-2:8", "IDENT(x)@2:8-2:9", "EOF()@2:9-2:9")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Reading a token that's completed by a 'Matcher' produces the complete lexeme.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Reading tokens of multi-byte runes produces their byte offsets.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := newPushTestScanner()
+		source := "a\u00e9\U0001F600 b\n."
+
+		// Act.
+		var got, want []string
+
+		for _, token := range readAllTokens(s, newSliceReader([]rune(source))) {
+			got = append(got, fmt.Sprintf("%q@%d-%d", token.Span.Text(source), token.Span.Start.Offset, token.Span.End.Offset))
+		}
+
+		want = newSlice(`"a"@0-1`, `"é"@1-3`, `"😀"@3-7`, `" "@7-8`, `"b"@8-9`, `"\n"@9-10`, `"."@10-11`, `""@11-11`)
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Reading tokens of multi-byte runes produces their byte offsets.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+}
+
 // Read n amount of tokens from scanner.
 func readN[S comparable, V any](scanner *scanner.Scanner[S, V], rdr scanner.SymbolReader[S], n int) []V {
 	tokens := make([]V, 0, n)
@@ -469,6 +701,28 @@ func readN[S comparable, V any](scanner *scanner.Scanner[S, V], rdr scanner.Symb
 	return tokens
 }
 
+// Read n amount of tokens (including their lexeme and location) from scanner.
+func readTokensN[S comparable, V any](s *scanner.Scanner[S, V], rdr scanner.SymbolReader[S], n int) []scanner.Token[S, V] {
+	tokens := make([]scanner.Token[S, V], 0, n)
+
+	for idx := 0; idx < n; idx += 1 {
+		tokens = append(tokens, s.Next(rdr))
+	}
+
+	return tokens
+}
+
+// Utility: Return the human-readable representation ("VALUE(lexeme)@start-end") of tokens.
+func formatTokens[V any](tokens []scanner.Token[rune, V]) []string {
+	formatted := make([]string, 0, len(tokens))
+
+	for _, token := range tokens {
+		formatted = append(formatted, fmt.Sprintf("%v(%s)@%s-%s", token.Value, string(token.Lexeme), token.Span.Start, token.Span.End))
+	}
+
+	return formatted
+}
+
 // Utility: Return a slice of T, containing args.
 func newSlice[T any](args ...T) []T {
 	container := make([]T, len(args))
@@ -498,3 +752,38 @@ func (rRdr *runeReader) ReadSymbol() (rune, error) {
 func (rRdr *runeReader) UnreadSymbol() error {
 	return rRdr.rdr.UnreadRune()
 }
+
+// A [scanner.SymbolReader] implementation backed by a slice, which supports unreading any amount of symbols.
+type sliceReader[S comparable] struct {
+	data []S
+	pos  int
+}
+
+// Returns a new [scanner.SymbolReader] that reads the symbols in data.
+func newSliceReader[S comparable](data []S) *sliceReader[S] {
+	return &sliceReader[S]{data: data}
+}
+
+// ReadSymbol reads the next symbol.
+func (sRdr *sliceReader[S]) ReadSymbol() (S, error) {
+	var sym S
+
+	if sRdr.pos >= len(sRdr.data) {
+		return sym, io.EOF
+	}
+
+	sRdr.pos++
+
+	return sRdr.data[sRdr.pos-1], nil
+}
+
+// UnreadSymbol unreads the last symbol read.
+func (sRdr *sliceReader[S]) UnreadSymbol() error {
+	if sRdr.pos == 0 {
+		return io.ErrUnexpectedEOF
+	}
+
+	sRdr.pos--
+
+	return nil
+}
@@ -0,0 +1,251 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "scanner" package.
+package scanner_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/pos"
+	"github.com/kdeconinck/align/internal/pkg/scanner"
+)
+
+// UT: Read tokens with different line terminators.
+func TestScanner_LineTerminators(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		terminators pos.Terminators
+		want        []string
+	}{
+		"By default, a '\\r' that isn't followed by a '\\n' doesn't end a line.": {
+			terminators: pos.DefaultTerminators,
+			want: newSlice(
+				"IDENT(a)@1:1-1:2", "WS( )@1:2-1:3", "CR(\r)@1:3-1:3", "HASH(#)@1:3-1:4", "IDENT(b)@1:4-1:5",
+				"CR(\r)@1:5-1:5", "NL(\n)@1:5-2:1", "DIRECTIVE(#c)@2:1-2:3", "LS(\u2028)@2:3-2:4", "HASH(#)@2:4-2:5",
+				"IDENT(d)@2:5-2:6", "EOF()@2:6-2:6",
+			),
+		},
+		"With CR and CRLF, both a '\\r' and a \"\\r\\n\" end a line.": {
+			terminators: pos.CR | pos.CRLF,
+			want: newSlice(
+				"IDENT(a)@1:1-1:2", "TRAIL( )@1:2-1:3", "CR(\r)@1:3-2:1", "DIRECTIVE(#b)@2:1-2:3", "CR(\r)@2:3-3:1",
+				"NL(\n)@3:1-3:1", "DIRECTIVE(#c)@3:1-3:3", "LS(\u2028)@3:3-3:4", "HASH(#)@3:4-3:5",
+				"IDENT(d)@3:5-3:6", "EOF()@3:6-3:6",
+			),
+		},
+		"With all terminators, the Unicode line separator ends a line.": {
+			terminators: pos.AllTerminators,
+			want: newSlice(
+				"IDENT(a)@1:1-1:2", "TRAIL( )@1:2-1:3", "CR(\r)@1:3-2:1", "DIRECTIVE(#b)@2:1-2:3", "CR(\r)@2:3-3:1",
+				"NL(\n)@3:1-3:1", "DIRECTIVE(#c)@3:1-3:3", "LS(\u2028)@3:3-4:1", "DIRECTIVE(#d)@4:1-4:3",
+				"EOF()@4:3-4:3",
+			),
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			s := newTerminatorScanner(tc.terminators)
+
+			// Act.
+			got := formatTokens(readAllTokens(s, newSliceReader([]rune("a \r#b\r\n#c\u2028#d"))))
+
+			// Assert.
+			assert.EqualSf(t, got, tc.want, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: %q.\033[0m\n"+
+				"\033[31mActual:   %q.\033[0m\n\n", tcName, tc.want, got)
+		})
+	}
+}
+
+// UT: Match a pattern that ends with 'LineEnd' before a '\r' with CRLF as the only line terminator.
+func TestScanner_LineEndWithCRLF(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	s := newTerminatorScanner(pos.CRLF)
+
+	// Act.
+	got := formatTokens(readAllTokens(s, newSliceReader([]rune("a \rb \r\n"))))
+	want := newSlice(
+		"IDENT(a)@1:1-1:2", "WS( )@1:2-1:3", "CR(\r)@1:3-1:3", "IDENT(b)@1:3-1:4", "TRAIL( )@1:4-1:5",
+		"CR(\r)@1:5-1:5", "NL(\n)@1:5-2:1", "EOF()@2:1-2:1",
+	)
+
+	// Assert.
+	assert.EqualSf(t, got, want, "\n\n"+
+		"UT Name:  With CRLF only, 'LineEnd' only holds before a '\\r' that's followed by a '\\n'.\n"+
+		"\033[32mExpected: %q.\033[0m\n"+
+		"\033[31mActual:   %q.\033[0m\n\n", want, got)
+}
+
+// UT: Set the Unicode line terminators on a builder for bytes.
+func TestScannerBuilder_SetLineTerminatorsPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for tcName, tc := range map[string]struct {
+		terminators pos.Terminators
+	}{
+		"Setting the line separator as a line terminator for bytes causes a panic.": {
+			terminators: pos.LF | pos.LS,
+		},
+		"Setting the paragraph separator as a line terminator for bytes causes a panic.": {
+			terminators: pos.LF | pos.PS,
+		},
+	} {
+		tcName, tc := tcName, tc // Rebind: Needed for parallel support.
+
+		t.Run(tcName, func(t *testing.T) {
+			t.Parallel() // Enable parallel execution.
+
+			// Arrange.
+			handler := func() {
+				scanner.NewScannerBuilder[byte, string]().SetLineTerminators(tc.terminators)
+			}
+
+			// Act / assert.
+			assert.Panicf(t, handler, "\n\n"+
+				"UT Name:  %s\n"+
+				"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+				"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n", tcName)
+		})
+	}
+}
+
+// UT: Compare the tokens of inputs with different line terminators, produced by the various ways of scanning.
+func TestScanner_LineTerminatorsDifferential(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	for _, terminators := range []pos.Terminators{
+		pos.DefaultTerminators, pos.LF, pos.CRLF, pos.CR | pos.CRLF, pos.CR | pos.LF, pos.AllTerminators,
+	} {
+		// Arrange.
+		rnd := rand.New(rand.NewSource(int64(terminators)))
+		alphabet := []rune("#a \r\n\u2028")
+		randomText := func(size int) []rune {
+			text := make([]rune, size)
+
+			for idx := range text {
+				text[idx] = alphabet[rnd.Intn(len(alphabet))]
+			}
+
+			return text
+		}
+
+		relexer := newTerminatorScanner(terminators)
+		edited := randomText(30)
+		tokens := readAllTokens(relexer, newSliceReader(edited))
+
+		for range 200 {
+			input := randomText(rnd.Intn(60))
+			want := formatTokensWithLookahead(readAllTokens(newTerminatorScanner(terminators), newSliceReader(input)))
+
+			// Act.
+			got := formatTokensWithLookahead(newTerminatorScanner(terminators).ScanParallel(input, 1+rnd.Intn(4)))
+
+			ps := scanner.NewPushScanner(newTerminatorScanner(terminators))
+			var pushed []scanner.Token[rune, string]
+
+			for _, r := range input {
+				pushed = append(pushed, ps.Feed([]rune{r})...)
+			}
+
+			gotPushed := formatTokensWithLookahead(append(pushed, ps.Close()...))
+
+			start := rnd.Intn(len(edited) + 1)
+			end := start + rnd.Intn(len(edited)-start+1)
+			text := randomText(rnd.Intn(4))
+			edit := scanner.Edit[rune]{Span: spanOfWith(edited, start, end, terminators), Text: text}
+			edited = append(append(append([]rune(nil), edited[:start]...), text...), edited[end:]...)
+			tokens, _ = relexer.Relex(tokens, edit)
+			gotRelexed := formatTokensWithLookahead(tokens)
+			wantRelexed := formatTokensWithLookahead(readAllTokens(newTerminatorScanner(terminators), newSliceReader(edited)))
+
+			// Assert.
+			assert.EqualSf(t, got, want, "\n\n"+
+				"UT Name:  Scanning %q in parallel (terminators %05b) produces the same tokens as scanning it sequentially.\n"+
+				"\033[32mExpected: %q.\033[0m\n"+
+				"\033[31mActual:   %q.\033[0m\n\n", string(input), terminators, want, got)
+
+			assert.EqualSf(t, gotPushed, want, "\n\n"+
+				"UT Name:  Feeding %q (terminators %05b) symbol by symbol produces the same tokens as scanning it.\n"+
+				"\033[32mExpected: %q.\033[0m\n"+
+				"\033[31mActual:   %q.\033[0m\n\n", string(input), terminators, want, gotPushed)
+
+			assert.EqualSf(t, gotRelexed, wantRelexed, "\n\n"+
+				"UT Name:  Relexing %q (terminators %05b) produces the same tokens as scanning it again.\n"+
+				"\033[32mExpected: %q.\033[0m\n"+
+				"\033[31mActual:   %q.\033[0m\n\n", string(edited), terminators, wantRelexed, gotRelexed)
+		}
+	}
+}
+
+// Returns a [scanner.Scanner] with patterns that depend on the line terminators in terminators.
+func newTerminatorScanner(terminators pos.Terminators) *scanner.Scanner[rune, string] {
+	spaces := scanner.RepeatAtLeast(0, scanner.Literal[rune, string](' '))
+
+	return scanner.NewScannerBuilder[rune, string]().
+		SetLineTerminators(terminators).
+		Add(scanner.Sequence(
+			scanner.LineStart[rune, string](), scanner.Literal[rune, string]('#'), scanner.ASCIIIdentifier[string](),
+		), "DIRECTIVE").
+		Add(scanner.Literal[rune, string]('#'), "HASH").
+		Add(scanner.Sequence(spaces, scanner.LineEnd[rune, string]()), "TRAIL").
+		Add(scanner.RepeatAtLeast(1, scanner.Literal[rune, string](' ')), "WS").
+		Add(scanner.Literal[rune, string]('\r'), "CR").
+		Add(scanner.Literal[rune, string]('\n'), "NL").
+		Add(scanner.Literal[rune, string]('\u2028'), "LS").
+		Add(scanner.ASCIIIdentifier[string](), "IDENT").
+		Build("ILLEGAL", "EOF")
+}
+
+// Returns the span between the offsets start and end of input, where lines end with one of the line terminators in
+// terminators.
+func spanOfWith(input []rune, start, end int, terminators pos.Terminators) pos.Span {
+	span, prev := pos.Span{Start: pos.New(), End: pos.New()}, rune(0)
+
+	for offset, r := range input[:end] {
+		if offset == start {
+			span.Start = span.End
+		}
+
+		terminators.Advance(&span.End, prev, r, len(string(r)))
+		prev = r
+	}
+
+	if start == end {
+		span.Start = span.End
+	}
+
+	return span
+}
@@ -0,0 +1,108 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a mechanism for transforming patterns into a set of tokens.
+package scanner
+
+import (
+	"unicode/utf8"
+
+	"github.com/kdeconinck/align/internal/pkg/pos"
+)
+
+// Token is a token that's produced by a [Scanner].
+type Token[S comparable, V any] struct {
+	// Value is the value of the pattern that matched the token.
+	Value V
+
+	// Lexeme contains the symbols of the token.
+	Lexeme []S
+
+	// Span is the location of the token in the input.
+	Span pos.Span
+
+	// Lookahead is the amount of symbols after the token that were read to decide where the token ends.
+	Lookahead int
+
+	// Data is the value that's computed by the action of the pattern (see [WithAction]), if any.
+	Data any
+}
+
+// Diagnostic is a problem that's detected in the input.
+type Diagnostic struct {
+	// Span is the location of the problem in the input.
+	Span pos.Span
+
+	// Message is the human-readable description of the problem.
+	Message string
+}
+
+// String returns the human-readable representation of the diagnostic.
+func (d Diagnostic) String() string {
+	return d.Span.Start.String() + ": " + d.Message
+}
+
+// Advances p over sym, which follows the symbol prev (as a rune), where lines end with one of the line terminators in
+// terminators. Returns sym as a rune (0 if S isn't byte or rune).
+// A rune occupies the length of its UTF-8 encoding (an invalid rune occupies the length of [utf8.RuneError]), while any
+// other symbol occupies a single byte. Symbols that aren't of type byte or rune advance the column of p.
+func advance[S comparable](p *pos.Position, terminators pos.Terminators, prev rune, sym S) rune {
+	switch v := any(sym).(type) {
+	case rune:
+		width := utf8.RuneLen(v)
+
+		if width == -1 {
+			width = utf8.RuneLen(utf8.RuneError)
+		}
+
+		terminators.Advance(p, prev, v, width)
+
+		return v
+
+	case byte:
+		terminators.Advance(p, prev, rune(v), 1)
+
+		return rune(v)
+
+	default:
+		terminators.Advance(p, prev, 0, 1)
+
+		return 0
+	}
+}
+
+// Returns sym as a rune and true if S is byte or rune.
+func runeOf[S comparable](sym S) (rune, bool) {
+	switch v := any(sym).(type) {
+	case rune:
+		return v, true
+
+	case byte:
+		return rune(v), true
+
+	default:
+		return 0, false
+	}
+}
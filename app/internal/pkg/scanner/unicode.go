@@ -0,0 +1,211 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// Package scanner implements a mechanism for transforming patterns into a set of tokens.
+package scanner
+
+import (
+	"unicode"
+
+	"github.com/kdeconinck/align/internal/pkg/automata/nfa"
+)
+
+// A set of runes defined by Unicode range tables.
+// A rune is a member if it's part of any table in include and NOT part of any table in exclude.
+// When fold is set, a rune is also a member if any rune that's equivalent under Unicode simple case folding is.
+//
+// NOTE: This type is always used as a pointer, which makes it comparable (as required by [nfa.Class]).
+type runeClass struct {
+	include []*unicode.RangeTable
+	exclude []*unicode.RangeTable
+	fold    bool
+}
+
+// Contains reports whether r is a member of the class.
+func (class *runeClass) Contains(r rune) bool {
+	if !class.fold {
+		return class.containsExact(r)
+	}
+
+	for f := r; ; {
+		if class.containsExact(f) {
+			return true
+		}
+
+		if f = unicode.SimpleFold(f); f == r {
+			return false
+		}
+	}
+}
+
+// Reports whether r is a member of the class, without applying case folding.
+func (class *runeClass) containsExact(r rune) bool {
+	return unicode.IsOneOf(class.include, r) && !unicode.IsOneOf(class.exclude, r)
+}
+
+// A [Fragment] that matches a single rune that's a member of a [runeClass].
+type fragClass[V any] struct {
+	class *runeClass
+}
+
+// InTable creates a [Fragment] that matches a single rune that's a member of any of the given tables.
+// The tables are compiled into a single transition, regardless of the amount of runes they contain.
+// Panics if no tables are provided.
+func InTable[V any](tables ...*unicode.RangeTable) Fragment[rune, V] {
+	if len(tables) == 0 {
+		panic("InTable: tables must have elements")
+	}
+
+	return fragClass[V]{
+		class: &runeClass{include: tables},
+	}
+}
+
+// Category creates a [Fragment] that matches a single rune in the Unicode category with the given name (e.g., "L",
+// "Lu", "Nd" or "Pc"). The names are the ones used by [unicode.Categories].
+// Panics if the category is unknown.
+func Category[V any](name string) Fragment[rune, V] {
+	table, ok := unicode.Categories[name]
+
+	if !ok {
+		panic("Category: unknown category \"" + name + "\"")
+	}
+
+	return InTable[V](table)
+}
+
+// Build creates a single class transition.
+func (frag fragClass[V]) Build(machine *nfa.Nfa[rune, V], startState *nfa.State[rune, V]) *nfa.State[rune, V] {
+	return machine.AddClass(startState, frag.class)
+}
+
+// Predefined identifier definitions.
+var (
+	// The runes that are excluded from the ID_Start and ID_Continue properties (see UAX #31).
+	idExclusions = []*unicode.RangeTable{unicode.Pattern_Syntax, unicode.Pattern_White_Space}
+
+	// The runes that are part of ID_Start, but NOT of XID_Start (closure under NFKC).
+	xidStartExclusions = &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 0x037a, Hi: 0x037a, Stride: 1},
+			{Lo: 0x0e33, Hi: 0x0eb3, Stride: 0x80},
+			{Lo: 0x309b, Hi: 0x309c, Stride: 1},
+			{Lo: 0xfc5e, Hi: 0xfc63, Stride: 1},
+			{Lo: 0xfdfa, Hi: 0xfdfb, Stride: 1},
+			{Lo: 0xfe70, Hi: 0xfe7e, Stride: 2},
+			{Lo: 0xff9e, Hi: 0xff9f, Stride: 1},
+		},
+	}
+
+	// The runes that are part of ID_Continue, but NOT of XID_Continue (closure under NFKC).
+	xidContinueExclusions = &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 0x037a, Hi: 0x037a, Stride: 1},
+			{Lo: 0x309b, Hi: 0x309c, Stride: 1},
+			{Lo: 0xfc5e, Hi: 0xfc63, Stride: 1},
+			{Lo: 0xfdfa, Hi: 0xfdfb, Stride: 1},
+			{Lo: 0xfe70, Hi: 0xfe7e, Stride: 2},
+		},
+	}
+
+	// The runes allowed in an identifier (after the first rune) in most ASCII based languages: [0-9A-Z_a-z].
+	asciiIdentContinue = &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: '0', Hi: '9', Stride: 1},
+			{Lo: 'A', Hi: 'Z', Stride: 1},
+			{Lo: '_', Hi: '_', Stride: 1},
+			{Lo: 'a', Hi: 'z', Stride: 1},
+		},
+		LatinOffset: 4,
+	}
+
+	// The runes allowed at the start of an identifier in most ASCII based languages: [A-Z_a-z].
+	asciiIdentStart = &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 'A', Hi: 'Z', Stride: 1},
+			{Lo: '_', Hi: '_', Stride: 1},
+			{Lo: 'a', Hi: 'z', Stride: 1},
+		},
+		LatinOffset: 3,
+	}
+
+	// The '_' rune.
+	underscore = &unicode.RangeTable{
+		R16:         []unicode.Range16{{Lo: '_', Hi: '_', Stride: 1}},
+		LatinOffset: 1,
+	}
+
+	xidStartClass = &runeClass{
+		include: []*unicode.RangeTable{unicode.L, unicode.Nl, unicode.Other_ID_Start},
+		exclude: append([]*unicode.RangeTable{xidStartExclusions}, idExclusions...),
+	}
+
+	xidContinueClass = &runeClass{
+		include: []*unicode.RangeTable{
+			unicode.L, unicode.Nl, unicode.Other_ID_Start, unicode.Mn, unicode.Mc, unicode.Nd, unicode.Pc,
+			unicode.Other_ID_Continue,
+		},
+		exclude: append([]*unicode.RangeTable{xidContinueExclusions}, idExclusions...),
+	}
+
+	goIdentStartClass    = &runeClass{include: []*unicode.RangeTable{unicode.Letter, underscore}}
+	goIdentContinueClass = &runeClass{include: []*unicode.RangeTable{unicode.Letter, unicode.Nd, underscore}}
+	asciiIdentStartClass = &runeClass{include: []*unicode.RangeTable{asciiIdentStart}}
+	asciiIdentContClass  = &runeClass{include: []*unicode.RangeTable{asciiIdentContinue}}
+)
+
+// XIDStart creates a [Fragment] that matches a single rune with the XID_Start property (see UAX #31).
+func XIDStart[V any]() Fragment[rune, V] {
+	return fragClass[V]{class: xidStartClass}
+}
+
+// XIDContinue creates a [Fragment] that matches a single rune with the XID_Continue property (see UAX #31).
+func XIDContinue[V any]() Fragment[rune, V] {
+	return fragClass[V]{class: xidContinueClass}
+}
+
+// XIDIdentifier creates a [Fragment] that matches a Unicode identifier (see UAX #31): A rune with the XID_Start
+// property, followed by zero or more runes with the XID_Continue property.
+func XIDIdentifier[V any]() Fragment[rune, V] {
+	return Sequence(XIDStart[V](), RepeatAtLeast(0, XIDContinue[V]()))
+}
+
+// GoIdentifier creates a [Fragment] that matches an identifier as defined by the Go specification: A letter or '_',
+// followed by zero or more letters, decimal digits or '_'.
+func GoIdentifier[V any]() Fragment[rune, V] {
+	return Sequence[rune, V](
+		fragClass[V]{class: goIdentStartClass},
+		RepeatAtLeast[rune, V](0, fragClass[V]{class: goIdentContinueClass}),
+	)
+}
+
+// ASCIIIdentifier creates a [Fragment] that matches an identifier consisting of ASCII characters only: [A-Za-z_],
+// followed by zero or more [A-Za-z0-9_].
+func ASCIIIdentifier[V any]() Fragment[rune, V] {
+	return Sequence[rune, V](
+		fragClass[V]{class: asciiIdentStartClass},
+		RepeatAtLeast[rune, V](0, fragClass[V]{class: asciiIdentContClass}),
+	)
+}
@@ -0,0 +1,289 @@
+// =====================================================================================================================
+// = LICENSE:       Copyright (c) 2025 Kevin De Coninck
+// =
+// =                Permission is hereby granted, free of charge, to any person
+// =                obtaining a copy of this software and associated documentation
+// =                files (the "Software"), to deal in the Software without
+// =                restriction, including without limitation the rights to use,
+// =                copy, modify, merge, publish, distribute, sublicense, and/or sell
+// =                copies of the Software, and to permit persons to whom the
+// =                Software is furnished to do so, subject to the following
+// =                conditions:
+// =
+// =                The above copyright notice and this permission notice shall be
+// =                included in all copies or substantial portions of the Software.
+// =
+// =                THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// =                EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES
+// =                OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// =                NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT
+// =                HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// =                WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// =                FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// =                OTHER DEALINGS IN THE SOFTWARE.
+// =====================================================================================================================
+
+// QA: Verify and measure the performance of the public API of the "scanner" package.
+package scanner_test
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/kdeconinck/align/internal/pkg/assert"
+	"github.com/kdeconinck/align/internal/pkg/scanner"
+)
+
+// UT: Use an 'InTable' without tables.
+func TestInTablePanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	handler := func() {
+		scanner.InTable[string]()
+	}
+
+	// Act / assert.
+	assert.Panicf(t, handler, "\n\n"+
+		"UT Name:  Using an 'InTable' fragment without tables causes a panic.\n"+
+		"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+		"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+}
+
+// UT: Use a 'Category' with an unknown name.
+func TestCategoryPanic(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	// Arrange.
+	handler := func() {
+		scanner.Category[string]("Unknown")
+	}
+
+	// Act / assert.
+	assert.Panicf(t, handler, "\n\n"+
+		"UT Name:  Using a 'Category' fragment with an unknown name causes a panic.\n"+
+		"\033[32mExpected: The function should 'panic'.\033[0m\n"+
+		"\033[31mActual:   The function did NOT 'panic'.\033[0m\n\n")
+}
+
+// UT: Build a [scanner.Scanner] using Unicode property fragments and tokenize a given input.
+func TestScanner_Unicode(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("Scanning a 'Category' fragment produces the value.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.Category[string]("Lu"), "UPPER").
+			Add(scanner.Category[string]("Ll"), "LOWER").
+			Build("ILLEGAL", "EOF")
+
+		rRdr := newRuneReader(strings.NewReader("ÀbΩ1"))
+
+		// Act.
+		got, want := readN(s, rRdr, 5), newSlice("UPPER", "LOWER", "UPPER", "ILLEGAL", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning a 'Category' fragment produces the value.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning an 'InTable' fragment produces the value.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		frag := scanner.RepeatAtLeast(1, scanner.InTable[string](unicode.Nd))
+
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(frag, "NUMBER").
+			Build("ILLEGAL", "EOF")
+
+		rRdr := newRuneReader(strings.NewReader("12٣٤x"))
+
+		// Act.
+		got, want := readN(s, rRdr, 3), newSlice("NUMBER", "ILLEGAL", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning an 'InTable' fragment produces the value.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning overlapping 'InTable' fragments respects the order of the patterns.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.InTable[string](unicode.Upper), "UPPER").
+			Add(scanner.InTable[string](unicode.Letter), "LETTER").
+			Add(scanner.Literal[rune, string]('x'), "X").
+			Build("ILLEGAL", "EOF")
+
+		rRdr := newRuneReader(strings.NewReader("Ayx"))
+
+		// Act.
+		got, want := readN(s, rRdr, 4), newSlice("UPPER", "LETTER", "LETTER", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning overlapping 'InTable' fragments respects the order of the patterns.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning an 'XIDIdentifier' fragment produces the value.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.Literal[rune, string]('i', 'f'), "KW_IF").
+			Add(scanner.XIDIdentifier[string](), "IDENT").
+			Add(scanner.Literal[rune, string](' '), "WS").
+			Build("ILLEGAL", "EOF")
+
+		rRdr := newRuneReader(strings.NewReader("if iff café_1 变量 _x"))
+
+		// Act.
+		got := readN(s, rRdr, 11)
+		want := newSlice("KW_IF", "WS", "IDENT", "WS", "IDENT", "WS", "IDENT", "WS", "ILLEGAL", "IDENT", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning an 'XIDIdentifier' fragment produces the value.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning an 'XIDStart' fragment rejects runes that are NOT closed under NFKC.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.XIDStart[string](), "START").
+			Add(scanner.XIDContinue[string](), "CONTINUE").
+			Build("ILLEGAL", "EOF")
+
+		rRdr := newRuneReader(strings.NewReader("aำ1ͺ"))
+
+		// Act.
+		got, want := readN(s, rRdr, 5), newSlice("START", "CONTINUE", "CONTINUE", "ILLEGAL", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning an 'XIDStart' fragment rejects runes that are NOT closed under NFKC.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning a 'GoIdentifier' fragment produces the value.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.GoIdentifier[string](), "IDENT").
+			Add(scanner.Literal[rune, string](' '), "WS").
+			Build("ILLEGAL", "EOF")
+
+		rRdr := newRuneReader(strings.NewReader("_x1 ñ 1"))
+
+		// Act.
+		got, want := readN(s, rRdr, 6), newSlice("IDENT", "WS", "IDENT", "WS", "ILLEGAL", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning a 'GoIdentifier' fragment produces the value.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Scanning an 'ASCIIIdentifier' fragment produces the value.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		s := scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.ASCIIIdentifier[string](), "IDENT").
+			Build("ILLEGAL", "EOF")
+
+		rRdr := newRuneReader(strings.NewReader("Ab_9é"))
+
+		// Act.
+		got, want := readN(s, rRdr, 3), newSlice("IDENT", "ILLEGAL", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning an 'ASCIIIdentifier' fragment produces the value.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+}
+
+// UT: Build a [scanner.Scanner] with many distinct 'Category' fragments.
+func TestScanner_ManyClasses(t *testing.T) {
+	t.Parallel() // Enable parallel execution.
+
+	t.Run("Scanning 18 'Category' fragments produces the value of each category.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		names := []string{
+			"Lu", "Ll", "Lt", "Lm", "Lo", "Mn", "Mc", "Me", "Nd", "Nl", "No", "Pc", "Pd", "Ps", "Pe", "Pi", "Pf", "Po",
+		}
+
+		builder := scanner.NewScannerBuilder[rune, string]()
+
+		for _, name := range names {
+			builder.Add(scanner.Category[string](name), name)
+		}
+
+		s, err := builder.TryBuild("ILLEGAL", "EOF")
+
+		assert.Nilf(t, err, "\n\n"+
+			"UT Name:  Scanning 18 'Category' fragments produces the value of each category.\n"+
+			"\033[31mFatal error: %v.\033[0m\n\n", err)
+
+		rRdr := newRuneReader(strings.NewReader("Aa1_(!"))
+
+		// Act.
+		got, want := readN(s, rRdr, 7), newSlice("Lu", "Ll", "Nd", "Pc", "Ps", "Po", "EOF")
+
+		// Assert.
+		assert.EqualSf(t, got, want, "\n\n"+
+			"UT Name:  Scanning 18 'Category' fragments produces the value of each category.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", want, got)
+	})
+
+	t.Run("Building a 'Scanner' with more than 64 distinct classes returns an error.", func(t *testing.T) {
+		t.Parallel() // Enable parallel execution.
+
+		// Arrange.
+		builder := scanner.NewScannerBuilder[rune, string]()
+
+		for range 65 {
+			builder.Add(scanner.InTable[string](unicode.Letter), "LETTER")
+		}
+
+		// Act.
+		_, got := builder.TryBuild("ILLEGAL", "EOF")
+
+		// Assert.
+		assert.Errorf(t, got, scanner.ErrTooManyClasses, "\n\n"+
+			"UT Name:  Building a 'Scanner' with more than 64 distinct classes returns an error.\n"+
+			"\033[32mExpected: %v.\033[0m\n"+
+			"\033[31mActual:   %v.\033[0m\n\n", scanner.ErrTooManyClasses, got)
+	})
+}
+
+// Benchmark(s): Build a [scanner.Scanner] that recognizes Unicode identifiers.
+func BenchmarkBuildXIDIdentifier(b *testing.B) {
+	for b.Loop() {
+		scanner.NewScannerBuilder[rune, string]().
+			Add(scanner.XIDIdentifier[string](), "IDENT").
+			Build("ILLEGAL", "EOF")
+	}
+}